@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -20,13 +20,13 @@ type Account struct {
 	Name string `json:"Name,omitempty" xml:"Name,omitempty"`
 
 	// See Account Types
-	Type string `json:"Type,omitempty" xml:"Type,omitempty"`
+	Type AccountType `json:"Type,omitempty" xml:"Type,omitempty"`
 
 	// For bank accounts only (Account Type BANK)
 	BankAccountNumber string `json:"BankAccountNumber,omitempty" xml:"BankAccountNumber,omitempty"`
 
 	// Accounts with a status of ACTIVE can be updated to ARCHIVED. See Account Status Codes
-	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
+	Status AccountStatus `json:"Status,omitempty" xml:"Status,omitempty"`
 
 	// Description of the Account. Valid for all types of accounts except bank accounts (max length = 4000)
 	Description string `json:"Description,omitempty" xml:"Description,omitempty"`
@@ -50,7 +50,7 @@ type Account struct {
 	AccountID string `json:"AccountID,omitempty" xml:"AccountID,omitempty"`
 
 	// See Account Class Types
-	Class string `json:"Class,omitempty" xml:"-"`
+	Class AccountClass `json:"Class,omitempty" xml:"-"`
 
 	// If this is a system account then this element is returned. See System Account types. Note that non-system accounts may have this element set as either “” or null.
 	SystemAccount string `json:"SystemAccount,omitempty" xml:"-"`
@@ -65,7 +65,7 @@ type Account struct {
 	HasAttachments bool `json:"HasAttachments,omitempty" xml:"-"`
 
 	// Last modified date UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"-"`
 }
 
 //Accounts contains a collection of Accounts
@@ -73,20 +73,6 @@ type Accounts struct {
 	Accounts []Account `json:"Accounts,omitempty" xml:"Account,omitempty"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (a *Accounts) convertDates() error {
-	var err error
-	for n := len(a.Accounts) - 1; n >= 0; n-- {
-		a.Accounts[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(a.Accounts[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalAccount(accountResponseBytes []byte) (*Accounts, error) {
 	var accountResponse *Accounts
 	err := json.Unmarshal(accountResponseBytes, &accountResponse)
@@ -94,27 +80,43 @@ func unmarshalAccount(accountResponseBytes []byte) (*Accounts, error) {
 		return nil, err
 	}
 
-	err = accountResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return accountResponse, err
 }
 
+//Validate checks the accounts for problems Xero would reject them for, without sending them.
+//Create and Update call it before making a request
+func (a *Accounts) Validate() error {
+	v := &validator{}
+	for n, account := range a.Accounts {
+		v.check(field("Accounts", n, "Type"), account.Type.Validate())
+		v.check(field("Accounts", n, "Status"), account.Status.Validate())
+	}
+	return v.err()
+}
+
 //Create will create accounts given an Accounts struct
 func (a *Accounts) Create(provider *xerogolang.Provider, session goth.Session) (*Accounts, error) {
+	return a.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (a *Accounts) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Accounts, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := a.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body, err := xml.MarshalIndent(a, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	accountResponseBytes, err := provider.Create(session, "Accounts", additionalHeaders, body)
+	accountResponseBytes, err := provider.CreateContext(ctx, session, "Accounts", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -125,17 +127,27 @@ func (a *Accounts) Create(provider *xerogolang.Provider, session goth.Session) (
 //Update will update an account given an Accounts struct
 //This will only handle single account - you cannot update multiple accounts in a single call
 func (a *Accounts) Update(provider *xerogolang.Provider, session goth.Session) (*Accounts, error) {
+	return a.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (a *Accounts) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Accounts, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := a.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body, err := xml.MarshalIndent(a, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	accountResponseBytes, err := provider.Update(session, "Accounts/"+a.Accounts[0].AccountID, additionalHeaders, body)
+	accountResponseBytes, err := provider.UpdateContext(ctx, session, "Accounts/"+a.Accounts[0].AccountID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -146,6 +158,11 @@ func (a *Accounts) Update(provider *xerogolang.Provider, session goth.Session) (
 //FindAccountsModifiedSince will get all accounts modified after a specified date.
 //additional querystringParameters such as where and order can be added as a map
 func FindAccountsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Accounts, error) {
+	return FindAccountsModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindAccountsModifiedSinceContext is like FindAccountsModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindAccountsModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Accounts, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -154,7 +171,7 @@ func FindAccountsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	accountResponseBytes, err := provider.Find(session, "Accounts", additionalHeaders, querystringParameters)
+	accountResponseBytes, err := provider.FindContext(ctx, session, "Accounts", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -165,16 +182,26 @@ func FindAccountsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 //FindAccounts will get all accounts. These account will not have details like line items.
 //additional querystringParameters such as where and order can be added as a map
 func FindAccounts(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Accounts, error) {
-	return FindAccountsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindAccountsContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindAccountsContext is like FindAccounts but uses ctx for the request so that it can be cancelled or given a deadline
+func FindAccountsContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Accounts, error) {
+	return FindAccountsModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
 }
 
 //FindAccount will get a single account - accountID must be a GUID for an account
 func FindAccount(provider *xerogolang.Provider, session goth.Session, accountID string) (*Accounts, error) {
+	return FindAccountContext(context.Background(), provider, session, accountID)
+}
+
+//FindAccountContext is like FindAccount but uses ctx for the request so that it can be cancelled or given a deadline
+func FindAccountContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, accountID string) (*Accounts, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	accountResponseBytes, err := provider.Find(session, "Accounts/"+accountID, additionalHeaders, nil)
+	accountResponseBytes, err := provider.FindContext(ctx, session, "Accounts/"+accountID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -184,11 +211,16 @@ func FindAccount(provider *xerogolang.Provider, session goth.Session, accountID
 
 //RemoveAccount will get a single account - accountID must be a GUID for an account
 func RemoveAccount(provider *xerogolang.Provider, session goth.Session, accountID string) (*Accounts, error) {
+	return RemoveAccountContext(context.Background(), provider, session, accountID)
+}
+
+//RemoveAccountContext is like RemoveAccount but uses ctx for the request so that it can be cancelled or given a deadline
+func RemoveAccountContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, accountID string) (*Accounts, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	accountResponseBytes, err := provider.Remove(session, "Accounts/"+accountID, additionalHeaders)
+	accountResponseBytes, err := provider.RemoveContext(ctx, session, "Accounts/"+accountID, additionalHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -201,8 +233,8 @@ func GenerateExampleAccount() *Accounts {
 	account := Account{
 		Code:                    "9999",
 		Name:                    "Import/Exports",
-		Type:                    "SALES",
-		Status:                  "ACTIVE",
+		Type:                    AccountTypeSales,
+		Status:                  AccountStatusActive,
 		Description:             "Proceeds from importing/exporting latex",
 		TaxType:                 "OUTPUT2",
 		EnablePaymentsToAccount: false,
@@ -0,0 +1,173 @@
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/markbates/goth"
+)
+
+//Attachment is a file attached to a document such as an invoice, bank transaction or contact.
+//Attachments are supported on Invoices, Receipts, CreditNotes, RepeatingInvoices, BankTransactions,
+//BankTransfers, Contacts, Accounts, ManualJournals and PurchaseOrders.
+//See https://developer.xero.com/documentation/api/attachments
+type Attachment struct {
+	// Xero generated unique identifier for the attachment
+	AttachmentID string `json:"AttachmentID,omitempty"`
+
+	// The name of the file e.g. invoice.pdf
+	FileName string `json:"FileName,omitempty"`
+
+	// The URL the content of the attachment can be downloaded from
+	URL string `json:"Url,omitempty"`
+
+	// The content type of the file e.g. application/pdf
+	MimeType string `json:"MimeType,omitempty"`
+
+	// The size of the file in bytes
+	ContentLength int64 `json:"ContentLength,omitempty"`
+
+	// Whether the attachment is shown with the online invoice or credit note. Only available on ACCREC documents
+	IncludeOnline bool `json:"IncludeOnline,omitempty"`
+}
+
+//Attachments contains a collection of Attachments
+type Attachments struct {
+	Attachments []Attachment `json:"Attachments"`
+}
+
+func unmarshalAttachment(attachmentResponseBytes []byte) (*Attachments, error) {
+	var attachmentResponse *Attachments
+	err := json.Unmarshal(attachmentResponseBytes, &attachmentResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachmentResponse, err
+}
+
+//attachmentsEndpoint returns the endpoint of the attachments of a document e.g. "Invoices/{id}/Attachments"
+func attachmentsEndpoint(docType string, id string) string {
+	return docType + "/" + id + "/Attachments"
+}
+
+//attachmentMimeType returns mimeType, or a guess from the file's extension when it is empty
+func attachmentMimeType(fileName string, mimeType string) string {
+	if mimeType != "" {
+		return mimeType
+	}
+	if guessed := mime.TypeByExtension(path.Ext(fileName)); guessed != "" {
+		return guessed
+	}
+	return "application/octet-stream"
+}
+
+//FindAttachments lists the attachments of a document given its docType e.g. "Invoices" and id
+func FindAttachments(provider *xerogolang.Provider, session goth.Session, docType string, id string) (*Attachments, error) {
+	return FindAttachmentsContext(context.Background(), provider, session, docType, id)
+}
+
+//FindAttachmentsContext is like FindAttachments but uses ctx for the request so that it can be cancelled or given a deadline
+func FindAttachmentsContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, docType string, id string) (*Attachments, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	attachmentResponseBytes, err := provider.FindContext(ctx, session, attachmentsEndpoint(docType, id), additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalAttachment(attachmentResponseBytes)
+}
+
+//FindAttachment downloads the content of a document's attachment given its file name.
+//Xero needs the mimeType of the file - when it is empty it is guessed from the file name.
+//The content is streamed rather than read into memory and the caller must close the returned reader
+func FindAttachment(provider *xerogolang.Provider, session goth.Session, docType string, id string, fileName string, mimeType string) (io.ReadCloser, error) {
+	return FindAttachmentContext(context.Background(), provider, session, docType, id, fileName, mimeType)
+}
+
+//FindAttachmentContext is like FindAttachment but uses ctx for the request so that it can be cancelled or given a deadline
+func FindAttachmentContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, docType string, id string, fileName string, mimeType string) (io.ReadCloser, error) {
+	additionalHeaders := map[string]string{
+		"Accept": attachmentMimeType(fileName, mimeType),
+	}
+
+	return provider.FindStreamContext(ctx, session, attachmentsEndpoint(docType, id)+"/"+url.PathEscape(fileName), additionalHeaders, nil)
+}
+
+//FindAttachmentByID downloads the content of a document's attachment given its AttachmentID.
+//Xero needs the mimeType of the file, which is returned by FindAttachments.
+//The content is streamed rather than read into memory and the caller must close the returned reader
+func FindAttachmentByID(provider *xerogolang.Provider, session goth.Session, docType string, id string, attachmentID string, mimeType string) (io.ReadCloser, error) {
+	return FindAttachmentByIDContext(context.Background(), provider, session, docType, id, attachmentID, mimeType)
+}
+
+//FindAttachmentByIDContext is like FindAttachmentByID but uses ctx for the request so that it can be cancelled or given a deadline
+func FindAttachmentByIDContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, docType string, id string, attachmentID string, mimeType string) (io.ReadCloser, error) {
+	additionalHeaders := map[string]string{
+		"Accept": attachmentMimeType("", mimeType),
+	}
+
+	return provider.FindStreamContext(ctx, session, attachmentsEndpoint(docType, id)+"/"+attachmentID, additionalHeaders, nil)
+}
+
+//CreateAttachment uploads content as a new attachment to a document, or adds a copy with a numbered file name
+//if it already has an attachment called fileName. When mimeType is empty it is guessed from the file name.
+//includeOnline shows the attachment with online ACCREC invoices and credit notes
+func CreateAttachment(provider *xerogolang.Provider, session goth.Session, docType string, id string, fileName string, mimeType string, includeOnline bool, content io.Reader) (*Attachments, error) {
+	return CreateAttachmentContext(context.Background(), provider, session, docType, id, fileName, mimeType, includeOnline, content)
+}
+
+//CreateAttachmentContext is like CreateAttachment but uses ctx for the request so that it can be cancelled or given a deadline
+func CreateAttachmentContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, docType string, id string, fileName string, mimeType string, includeOnline bool, content io.Reader) (*Attachments, error) {
+	return uploadAttachment(ctx, provider, session, "PUT", docType, id, fileName, mimeType, includeOnline, content)
+}
+
+//UpdateAttachment uploads content to a document, replacing the attachment called fileName if there is one.
+//When mimeType is empty it is guessed from the file name.
+//includeOnline shows the attachment with online ACCREC invoices and credit notes
+func UpdateAttachment(provider *xerogolang.Provider, session goth.Session, docType string, id string, fileName string, mimeType string, includeOnline bool, content io.Reader) (*Attachments, error) {
+	return UpdateAttachmentContext(context.Background(), provider, session, docType, id, fileName, mimeType, includeOnline, content)
+}
+
+//UpdateAttachmentContext is like UpdateAttachment but uses ctx for the request so that it can be cancelled or given a deadline
+func UpdateAttachmentContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, docType string, id string, fileName string, mimeType string, includeOnline bool, content io.Reader) (*Attachments, error) {
+	return uploadAttachment(ctx, provider, session, "POST", docType, id, fileName, mimeType, includeOnline, content)
+}
+
+//uploadAttachment sends content to the attachment endpoint of a document using method.
+//The content is read into memory so that the request can be retried
+func uploadAttachment(ctx context.Context, provider *xerogolang.Provider, session goth.Session, method string, docType string, id string, fileName string, mimeType string, includeOnline bool, content io.Reader) (*Attachments, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": attachmentMimeType(fileName, mimeType),
+	}
+
+	var querystringParameters map[string]string
+	if includeOnline {
+		querystringParameters = map[string]string{
+			"IncludeOnline": strconv.FormatBool(includeOnline),
+		}
+	}
+
+	body, err := ioutil.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentResponseBytes, err := provider.SendContext(ctx, session, method, attachmentsEndpoint(docType, id)+"/"+url.PathEscape(fileName), additionalHeaders, querystringParameters, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalAttachment(attachmentResponseBytes)
+}
@@ -0,0 +1,86 @@
+package accounting
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FindAttachments(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.Equal("/api.xro/2.0/Invoices/invoice-id/Attachments", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"Attachments": [{"AttachmentID": "attachment-id", "FileName": "bill.pdf", "MimeType": "application/pdf", "ContentLength": 1024}]}`)
+	}))
+	defer ts.Close()
+	provider, session := testProvider(t, ts)
+
+	attachments, err := FindAttachments(provider, session, "Invoices", "invoice-id")
+	a.NoError(err)
+	a.Equal([]Attachment{{AttachmentID: "attachment-id", FileName: "bill.pdf", MimeType: "application/pdf", ContentLength: 1024}}, attachments.Attachments)
+}
+
+func Test_FindAttachment(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.EscapedPath()+" "+r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", "application/pdf")
+		io.WriteString(w, "%PDF-1.4")
+	}))
+	defer ts.Close()
+	provider, session := testProvider(t, ts)
+
+	content, err := FindAttachment(provider, session, "Invoices", "invoice-id", "supplier bill.pdf", "")
+	a.NoError(err)
+	data, err := io.ReadAll(content)
+	a.NoError(err)
+	a.NoError(content.Close())
+	a.Equal("%PDF-1.4", string(data))
+
+	content, err = FindAttachmentByID(provider, session, "BankTransactions", "transaction-id", "attachment-id", "image/png")
+	a.NoError(err)
+	content.Close()
+
+	a.Equal([]string{
+		"/api.xro/2.0/Invoices/invoice-id/Attachments/supplier%20bill.pdf application/pdf",
+		"/api.xro/2.0/BankTransactions/transaction-id/Attachments/attachment-id image/png",
+	}, requests)
+}
+
+func Test_CreateAndUpdateAttachment(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.RequestURI()+" "+r.Header.Get("Content-Type")+" "+string(body))
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"Attachments": [{"AttachmentID": "attachment-id", "FileName": "bill.pdf", "IncludeOnline": true}]}`)
+	}))
+	defer ts.Close()
+	provider, session := testProvider(t, ts)
+
+	attachments, err := CreateAttachment(provider, session, "Invoices", "invoice-id", "bill.pdf", "", true, strings.NewReader("%PDF-1.4"))
+	a.NoError(err)
+	a.Equal("attachment-id", attachments.Attachments[0].AttachmentID)
+	a.True(attachments.Attachments[0].IncludeOnline)
+
+	_, err = UpdateAttachment(provider, session, "Contacts", "contact-id", "notes.txt", "text/plain", false, strings.NewReader("notes"))
+	a.NoError(err)
+
+	a.Equal([]string{
+		"PUT /api.xro/2.0/Invoices/invoice-id/Attachments/bill.pdf?IncludeOnline=true application/pdf %PDF-1.4",
+		"POST /api.xro/2.0/Contacts/contact-id/Attachments/notes.txt text/plain notes",
+	}, requests)
+}
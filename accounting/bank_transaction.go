@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -14,7 +14,7 @@ import (
 type BankTransaction struct {
 
 	// See Bank Transaction Types
-	Type string `json:"Type" xml:"Type"`
+	Type BankTransactionType `json:"Type" xml:"Type"`
 
 	// See Contacts
 	Contact Contact `json:"Contact" xml:"Contact"`
@@ -26,7 +26,7 @@ type BankTransaction struct {
 	IsReconciled bool `json:"IsReconciled,omitempty" xml:"IsReconciled,omitempty"`
 
 	// Date of transaction – YYYY-MM-DD
-	Date string `json:"DateString,omitempty" xml:"Date,omitempty"`
+	Date Date `json:"DateString,omitempty" xml:"Date,omitempty"`
 
 	// Reference for the transaction. Only supported for SPEND and RECEIVE transactions.
 	Reference string `json:"Reference,omitempty" xml:"Reference,omitempty"`
@@ -35,25 +35,25 @@ type BankTransaction struct {
 	CurrencyCode string `json:"CurrencyCode,omitempty" xml:"CurrencyCode,omitempty"`
 
 	// Exchange rate to base currency when money is spent or received. e.g. 0.7500 Only used for bank transactions in non base currency. If this isn’t specified for non base currency accounts then either the user-defined rate (preference) or the XE.com day rate will be used. Setting currency is only supported on overpayments.
-	CurrencyRate float64 `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
+	CurrencyRate Decimal `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
 
 	// URL link to a source document – shown as “Go to App Name”
 	URL string `json:"Url,omitempty" xml:"Url,omitempty"`
 
 	// See Bank Transaction Status Codes
-	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
+	Status BankTransactionStatus `json:"Status,omitempty" xml:"Status,omitempty"`
 
 	// Line amounts are exclusive of tax by default if you don’t specify this element. See Line Amount Types
-	LineAmountTypes string `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
 
 	// Total of bank transaction excluding taxes
-	SubTotal float64 `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
+	SubTotal Decimal `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
 
 	// Total tax on bank transaction
-	TotalTax float64 `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
+	TotalTax Decimal `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
 
 	// Total of bank transaction tax inclusive
-	Total float64 `json:"Total,omitempty" xml:"Total,omitempty"`
+	Total Decimal `json:"Total,omitempty" xml:"Total,omitempty"`
 
 	// Xero generated unique identifier for bank transaction
 	BankTransactionID string `json:"BankTransactionID,omitempty" xml:"BankTransactionID,omitempty"`
@@ -68,7 +68,7 @@ type BankTransaction struct {
 	OverpaymentID string `json:"OverpaymentID,omitempty" xml:"-"`
 
 	// Last modified date UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"-"`
 
 	// Boolean to indicate if a bank transaction has an attachment
 	HasAttachments bool `json:"HasAttachments,omitempty" xml:"-"`
@@ -79,20 +79,6 @@ type BankTransactions struct {
 	BankTransactions []BankTransaction `json:"BankTransactions" xml:"BankTransaction"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (b *BankTransactions) convertDates() error {
-	var err error
-	for n := len(b.BankTransactions) - 1; n >= 0; n-- {
-		b.BankTransactions[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(b.BankTransactions[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalBankTransaction(bankTransactionResponseBytes []byte) (*BankTransactions, error) {
 	var bankTransactionResponse *BankTransactions
 	err := json.Unmarshal(bankTransactionResponseBytes, &bankTransactionResponse)
@@ -100,27 +86,54 @@ func unmarshalBankTransaction(bankTransactionResponseBytes []byte) (*BankTransac
 		return nil, err
 	}
 
-	err = bankTransactionResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return bankTransactionResponse, err
 }
 
+//Validate checks the bank transactions for problems Xero would reject them for, without sending them.
+//Fields that are only needed to create a bank transaction are checked when it has no BankTransactionID.
+//Create and Update call it before making a request
+func (b *BankTransactions) Validate() error {
+	v := &validator{}
+	for n, bankTransaction := range b.BankTransactions {
+		v.check(field("BankTransactions", n, "Type"), bankTransaction.Type.Validate())
+		v.check(field("BankTransactions", n, "Status"), bankTransaction.Status.Validate())
+		v.check(field("BankTransactions", n, "LineAmountTypes"), bankTransaction.LineAmountTypes.Validate())
+		v.maxLength(field("BankTransactions", n, "Reference"), bankTransaction.Reference, 255)
+		v.lineItems(field("BankTransactions", n, "LineItems"), bankTransaction.LineItems, false)
+
+		if bankTransaction.BankTransactionID == "" {
+			v.required(field("BankTransactions", n, "Type"), bankTransaction.Type != "")
+			v.contact(field("BankTransactions", n, "Contact"), bankTransaction.Contact)
+			v.required(field("BankTransactions", n, "LineItems"), len(bankTransaction.LineItems) > 0)
+			v.required(field("BankTransactions", n, "BankAccount"), bankTransaction.BankAccount.AccountID != "" || bankTransaction.BankAccount.Code != "")
+		}
+	}
+	return v.err()
+}
+
 //Create will create BankTransactions given an BankTransactions struct
 func (b *BankTransactions) Create(provider *xerogolang.Provider, session goth.Session) (*BankTransactions, error) {
+	return b.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (b *BankTransactions) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*BankTransactions, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := b.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body, err := xml.MarshalIndent(b, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	bankTransactionResponseBytes, err := provider.Create(session, "BankTransactions", additionalHeaders, body)
+	bankTransactionResponseBytes, err := provider.CreateContext(ctx, session, "BankTransactions", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -131,17 +144,27 @@ func (b *BankTransactions) Create(provider *xerogolang.Provider, session goth.Se
 //Update will update a BankTransaction given a BankTransactions struct
 //This will only handle single BankTransaction - you cannot update multiple BankTransactions in a single call
 func (b *BankTransactions) Update(provider *xerogolang.Provider, session goth.Session) (*BankTransactions, error) {
+	return b.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (b *BankTransactions) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*BankTransactions, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := b.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body, err := xml.MarshalIndent(b, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	bankTransactionResponseBytes, err := provider.Update(session, "BankTransactions/"+b.BankTransactions[0].BankTransactionID, additionalHeaders, body)
+	bankTransactionResponseBytes, err := provider.UpdateContext(ctx, session, "BankTransactions/"+b.BankTransactions[0].BankTransactionID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -154,6 +177,11 @@ func (b *BankTransactions) Update(provider *xerogolang.Provider, session goth.Se
 //If you need details then then add a 'page' querystringParameter and get 100 BankTransactions at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindBankTransactionsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*BankTransactions, error) {
+	return FindBankTransactionsModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindBankTransactionsModifiedSinceContext is like FindBankTransactionsModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindBankTransactionsModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*BankTransactions, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -162,7 +190,7 @@ func FindBankTransactionsModifiedSince(provider *xerogolang.Provider, session go
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	bankTransactionResponseBytes, err := provider.Find(session, "BankTransactions", additionalHeaders, querystringParameters)
+	bankTransactionResponseBytes, err := provider.FindContext(ctx, session, "BankTransactions", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -174,16 +202,36 @@ func FindBankTransactionsModifiedSince(provider *xerogolang.Provider, session go
 //If you need details then then add a 'page' querystringParameter and get 100 BankTransactions at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindBankTransactions(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*BankTransactions, error) {
-	return FindBankTransactionsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindBankTransactionsContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindBankTransactionsContext is like FindBankTransactions but uses ctx for the request so that it can be cancelled or given a deadline
+func FindBankTransactionsContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*BankTransactions, error) {
+	return FindBankTransactionsModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//IterateBankTransactions returns an Iterator over every bank transaction matching querystringParameters
+func IterateBankTransactions(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) *Iterator[BankTransaction] {
+	return iterate(ctx, provider, session, querystringParameters, FindBankTransactionsContext, func(bankTransactions *BankTransactions) []BankTransaction { return bankTransactions.BankTransactions })
+}
+
+//ForEachBankTransaction calls fn with every bank transaction matching querystringParameters, stopping at the first error fn returns
+func ForEachBankTransaction(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string, fn func(bankTransaction BankTransaction) error) error {
+	return ForEach(IterateBankTransactions(ctx, provider, session, querystringParameters), fn)
 }
 
 //FindBankTransaction will get a single BankTransaction - BankTransactionID can be a GUID for an BankTransaction or an BankTransaction number
 func FindBankTransaction(provider *xerogolang.Provider, session goth.Session, bankTransactionID string) (*BankTransactions, error) {
+	return FindBankTransactionContext(context.Background(), provider, session, bankTransactionID)
+}
+
+//FindBankTransactionContext is like FindBankTransaction but uses ctx for the request so that it can be cancelled or given a deadline
+func FindBankTransactionContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, bankTransactionID string) (*BankTransactions, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	bankTransactionResponseBytes, err := provider.Find(session, "BankTransactions/"+bankTransactionID, additionalHeaders, nil)
+	bankTransactionResponseBytes, err := provider.FindContext(ctx, session, "BankTransactions/"+bankTransactionID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -195,8 +243,8 @@ func FindBankTransaction(provider *xerogolang.Provider, session goth.Session, ba
 func GenerateExampleBankTransaction() *BankTransactions {
 	lineItem := LineItem{
 		Description: "Importing & Exporting Services",
-		Quantity:    1.00,
-		UnitAmount:  395.00,
+		Quantity:    MustParseDecimal("1.00"),
+		UnitAmount:  MustParseDecimal("395.00"),
 		AccountCode: "200",
 	}
 
@@ -205,11 +253,11 @@ func GenerateExampleBankTransaction() *BankTransactions {
 	}
 
 	bankTransaction := BankTransaction{
-		Type: "RECEIVE",
+		Type: BankTransactionTypeReceive,
 		Contact: Contact{
 			Name: "George Costanza",
 		},
-		Date:        helpers.TodayRFC3339(),
+		Date:        Today(),
 		LineItems:   []LineItem{},
 		BankAccount: bankAccount,
 	}
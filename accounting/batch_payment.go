@@ -1,19 +1,259 @@
 package accounting
 
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/markbates/goth"
+)
+
+//BatchPayment pays or receives payment for several invoices from one bank account in a single transaction.
+//The bank details are also used on Contacts to hold the details a contact's batch payments are made with
 type BatchPayment struct {
 
+	// The bank account the payments are made from or received into. See Accounts
+	Account *Account `json:"Account,omitempty" xml:"Account,omitempty"`
+
+	// Date the payments are made (YYYY-MM-DD) e.g. 2009-09-06
+	Date Date `json:"DateString,omitempty" xml:"Date,omitempty"`
+
+	// The payments in the batch. Each needs an Invoice and an Amount
+	Payments []Payment `json:"Payments,omitempty" xml:"Payments>Payment,omitempty"`
+
 	// A user defined bank account number.
-	BankAccountNumber string `json:"BankAccountNumber,omitempty" xml:"BankAccountNumber,omitempty"`
+	BankAccountNumber string `json:"BankAccountNumber,omitempty" xml:"-"`
 
 	// Full name of bank account
-	BankAccountName string `json:"BankAccountName,omitempty" xml:"BankAccountName,omitempty"`
+	BankAccountName string `json:"BankAccountName,omitempty" xml:"-"`
 
-	// Details of the Batch payment
+	// Details of the Batch payment (NZ only, max length = 18)
 	Details string `json:"Details,omitempty" xml:"Details,omitempty"`
 
-	// Code of the Batch payment
+	// Code of the Batch payment (NZ only, max length = 12)
 	Code string `json:"Code,omitempty" xml:"Code,omitempty"`
 
-	// Reference of the Batch payment
+	// Reference of the Batch payment (NZ only, max length = 12)
 	Reference string `json:"Reference,omitempty" xml:"Reference,omitempty"`
+
+	// Particulars of the Batch payment (NZ only, max length = 12)
+	Particulars string `json:"Particulars,omitempty" xml:"Particulars,omitempty"`
+
+	// Description shown on the bank statement (UK only, max length = 18)
+	Narrative string `json:"Narrative,omitempty" xml:"Narrative,omitempty"`
+
+	// Xero generated unique identifier for the batch payment
+	BatchPaymentID string `json:"BatchPaymentID,omitempty" xml:"BatchPaymentID,omitempty"`
+
+	// PAYBATCH for bill payments or RECBATCH for sales invoice payments
+	Type BatchPaymentType `json:"Type,omitempty" xml:"-"`
+
+	// AUTHORISED or DELETED
+	Status BatchPaymentStatus `json:"Status,omitempty" xml:"Status,omitempty"`
+
+	// The total of the payments in the batch
+	TotalAmount Decimal `json:"TotalAmount,omitempty" xml:"-"`
+
+	// Whether the batch payment has been reconciled
+	IsReconciled bool `json:"IsReconciled,omitempty" xml:"-"`
+
+	// UTC timestamp of last update to the batch payment
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"-"`
+}
+
+//BatchPayments is a collection of BatchPayments
+type BatchPayments struct {
+	BatchPayments []BatchPayment `json:"BatchPayments" xml:"BatchPayment"`
+}
+
+func unmarshalBatchPayment(batchPaymentResponseBytes []byte) (*BatchPayments, error) {
+	var batchPaymentResponse *BatchPayments
+	err := json.Unmarshal(batchPaymentResponseBytes, &batchPaymentResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return batchPaymentResponse, err
+}
+
+//Validate checks the batch payments for problems Xero would reject them for, without sending them.
+//Fields that are only needed to create a batch payment are checked when it has no BatchPaymentID.
+//Create calls it before making a request
+func (b *BatchPayments) Validate() error {
+	v := &validator{}
+	for n, batchPayment := range b.BatchPayments {
+		v.check(field("BatchPayments", n, "Type"), batchPayment.Type.Validate())
+		v.check(field("BatchPayments", n, "Status"), batchPayment.Status.Validate())
+		v.maxLength(field("BatchPayments", n, "Details"), batchPayment.Details, 18)
+		v.maxLength(field("BatchPayments", n, "Code"), batchPayment.Code, 12)
+		v.maxLength(field("BatchPayments", n, "Reference"), batchPayment.Reference, 12)
+		v.maxLength(field("BatchPayments", n, "Particulars"), batchPayment.Particulars, 12)
+		v.maxLength(field("BatchPayments", n, "Narrative"), batchPayment.Narrative, 18)
+
+		if batchPayment.BatchPaymentID == "" {
+			v.required(field("BatchPayments", n, "Account"), batchPayment.Account != nil && (batchPayment.Account.AccountID != "" || batchPayment.Account.Code != ""))
+			v.required(field("BatchPayments", n, "Date"), !batchPayment.Date.IsZero())
+			v.required(field("BatchPayments", n, "Payments"), len(batchPayment.Payments) > 0)
+			for p, payment := range batchPayment.Payments {
+				paymentField := fmt.Sprintf("%s[%d]", field("BatchPayments", n, "Payments"), p)
+				v.required(paymentField+".Invoice", payment.Invoice != nil && (payment.Invoice.InvoiceID != "" || payment.Invoice.InvoiceNumber != ""))
+				if payment.Amount.Sign() <= 0 {
+					v.add(paymentField+".Amount", "must be more than zero")
+				}
+			}
+		}
+	}
+	return v.err()
+}
+
+//Create will create batch payments given a BatchPayments struct
+func (b *BatchPayments) Create(provider *xerogolang.Provider, session goth.Session) (*BatchPayments, error) {
+	return b.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (b *BatchPayments) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*BatchPayments, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	err := b.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := xml.MarshalIndent(b, "  ", "	")
+	if err != nil {
+		return nil, err
+	}
+
+	batchPaymentResponseBytes, err := provider.CreateContext(ctx, session, "BatchPayments", additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalBatchPayment(batchPaymentResponseBytes)
+}
+
+//RemoveBatchPayment will delete a batch payment given its batchPaymentID by setting its status to DELETED.
+//The payments in the batch are deleted along with it
+func RemoveBatchPayment(provider *xerogolang.Provider, session goth.Session, batchPaymentID string) (*BatchPayments, error) {
+	return RemoveBatchPaymentContext(context.Background(), provider, session, batchPaymentID)
+}
+
+//RemoveBatchPaymentContext is like RemoveBatchPayment but uses ctx for the request so that it can be cancelled or given a deadline
+func RemoveBatchPaymentContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, batchPaymentID string) (*BatchPayments, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	deletion := &BatchPayments{
+		BatchPayments: []BatchPayment{
+			{
+				BatchPaymentID: batchPaymentID,
+				Status:         BatchPaymentStatusDeleted,
+			},
+		},
+	}
+
+	body, err := xml.MarshalIndent(deletion, "  ", "	")
+	if err != nil {
+		return nil, err
+	}
+
+	batchPaymentResponseBytes, err := provider.UpdateContext(ctx, session, "BatchPayments", additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalBatchPayment(batchPaymentResponseBytes)
+}
+
+//FindBatchPaymentsModifiedSince will get all BatchPayments modified after a specified date.
+//additional querystringParameters such as where and order can be added as a map
+func FindBatchPaymentsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*BatchPayments, error) {
+	return FindBatchPaymentsModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindBatchPaymentsModifiedSinceContext is like FindBatchPaymentsModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindBatchPaymentsModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*BatchPayments, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	if !modifiedSince.Equal(dayZero) {
+		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
+	}
+
+	batchPaymentResponseBytes, err := provider.FindContext(ctx, session, "BatchPayments", additionalHeaders, querystringParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalBatchPayment(batchPaymentResponseBytes)
+}
+
+//FindBatchPayments will get all BatchPayments.
+//additional querystringParameters such as where and order can be added as a map
+func FindBatchPayments(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*BatchPayments, error) {
+	return FindBatchPaymentsContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindBatchPaymentsContext is like FindBatchPayments but uses ctx for the request so that it can be cancelled or given a deadline
+func FindBatchPaymentsContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*BatchPayments, error) {
+	return FindBatchPaymentsModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//FindBatchPayment will get a single batch payment - batchPaymentID must be a GUID for a batch payment
+func FindBatchPayment(provider *xerogolang.Provider, session goth.Session, batchPaymentID string) (*BatchPayments, error) {
+	return FindBatchPaymentContext(context.Background(), provider, session, batchPaymentID)
+}
+
+//FindBatchPaymentContext is like FindBatchPayment but uses ctx for the request so that it can be cancelled or given a deadline
+func FindBatchPaymentContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, batchPaymentID string) (*BatchPayments, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	batchPaymentResponseBytes, err := provider.FindContext(ctx, session, "BatchPayments/"+batchPaymentID, additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalBatchPayment(batchPaymentResponseBytes)
+}
+
+//GenerateExampleBatchPayment Creates an Example batch payment paying each of the invoices in full
+func GenerateExampleBatchPayment(accountCode string, invoices []Invoice) *BatchPayments {
+	batchPayment := BatchPayment{
+		Account: &Account{
+			Code: accountCode,
+		},
+		Date:      Today(),
+		Reference: "AP run",
+		Payments:  []Payment{},
+	}
+
+	for _, invoice := range invoices {
+		payment := Payment{
+			Invoice: &Invoice{
+				InvoiceID: invoice.InvoiceID,
+			},
+			Amount: invoice.AmountDue,
+		}
+		batchPayment.Payments = append(batchPayment.Payments, payment)
+	}
+
+	batchPaymentCollection := &BatchPayments{
+		BatchPayments: []BatchPayment{},
+	}
+
+	batchPaymentCollection.BatchPayments = append(batchPaymentCollection.BatchPayments, batchPayment)
+
+	return batchPaymentCollection
 }
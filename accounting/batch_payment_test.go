@@ -0,0 +1,89 @@
+package accounting
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//batchPaymentServer records the method, path and body of each request and responds with a single batch payment
+func batchPaymentServer(requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*requests = append(*requests, r.Method+" "+r.URL.Path+" "+string(body))
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"BatchPayments": [{"BatchPaymentID": "batch-id", "Type": "PAYBATCH", "Status": "AUTHORISED", "DateString": "2018-07-01T00:00:00", "TotalAmount": 150.50, "Payments": [{"PaymentID": "payment-id", "Amount": 150.50, "Invoice": {"InvoiceID": "invoice-id"}}]}]}`)
+	}))
+}
+
+func Test_BatchPayments_Validate(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	invoices := []Invoice{{InvoiceID: "invoice-1", AmountDue: MustParseDecimal("100")}, {InvoiceID: "invoice-2", AmountDue: MustParseDecimal("50.50")}}
+	a.NoError(GenerateExampleBatchPayment("090", invoices).Validate())
+
+	batchPayments := &BatchPayments{BatchPayments: []BatchPayment{{
+		Reference: "Longer than twelve",
+		Payments:  []Payment{{Invoice: &Invoice{InvoiceID: "invoice-1"}}},
+	}}}
+	a.Equal(ValidationErrors{
+		{Field: "BatchPayments[0].Reference", Message: "must be at most 12 characters but is 18"},
+		{Field: "BatchPayments[0].Account", Message: "is required"},
+		{Field: "BatchPayments[0].Date", Message: "is required"},
+		{Field: "BatchPayments[0].Payments[0].Amount", Message: "must be more than zero"},
+	}, batchPayments.Validate())
+}
+
+func Test_BatchPayments_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	ts := batchPaymentServer(&requests)
+	defer ts.Close()
+	provider, session := testProvider(t, ts)
+
+	invoices := []Invoice{{InvoiceID: "invoice-id", AmountDue: MustParseDecimal("150.50")}}
+	batchPayments, err := GenerateExampleBatchPayment("090", invoices).Create(provider, session)
+	a.NoError(err)
+
+	batchPayment := batchPayments.BatchPayments[0]
+	a.Equal("batch-id", batchPayment.BatchPaymentID)
+	a.Equal(BatchPaymentTypePayBatch, batchPayment.Type)
+	a.Equal("150.5", batchPayment.TotalAmount.String())
+	a.Equal("invoice-id", batchPayment.Payments[0].Invoice.InvoiceID)
+
+	a.Len(requests, 1)
+	a.Contains(requests[0], "PUT /api.xro/2.0/BatchPayments ")
+	a.Contains(requests[0], "<Payments>")
+	a.Contains(requests[0], "<Amount>150.5</Amount>")
+	a.Contains(requests[0], "<Code>090</Code>")
+}
+
+func Test_FindAndRemoveBatchPayment(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	ts := batchPaymentServer(&requests)
+	defer ts.Close()
+	provider, session := testProvider(t, ts)
+
+	_, err := FindBatchPayments(provider, session, nil)
+	a.NoError(err)
+	_, err = FindBatchPayment(provider, session, "batch-id")
+	a.NoError(err)
+	_, err = RemoveBatchPayment(provider, session, "batch-id")
+	a.NoError(err)
+
+	a.Len(requests, 3)
+	a.Equal("GET /api.xro/2.0/BatchPayments ", requests[0])
+	a.Equal("GET /api.xro/2.0/BatchPayments/batch-id ", requests[1])
+	a.Contains(requests[2], "POST /api.xro/2.0/BatchPayments ")
+	a.Contains(requests[2], "<BatchPaymentID>batch-id</BatchPaymentID>")
+	a.Contains(requests[2], "<Status>DELETED</Status>")
+}
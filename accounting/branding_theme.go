@@ -1,10 +1,10 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -21,7 +21,7 @@ type BrandingTheme struct {
 	SortOrder float64 `json:"SortOrder,omitempty" xml:"SortOrder,omitempty"`
 
 	// UTC timestamp of creation date of branding theme
-	CreatedDateUTC string `json:"CreatedDateUTC,omitempty" xml:"CreatedDateUTC,omitempty"`
+	CreatedDateUTC DateTime `json:"CreatedDateUTC,omitempty" xml:"CreatedDateUTC,omitempty"`
 }
 
 //BrandingThemes contains a collection of BrandingThemes
@@ -29,20 +29,6 @@ type BrandingThemes struct {
 	BrandingThemes []BrandingTheme `json:"BrandingThemes" xml:"BrandingTheme"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (b *BrandingThemes) convertDates() error {
-	var err error
-	for n := len(b.BrandingThemes) - 1; n >= 0; n-- {
-		b.BrandingThemes[n].CreatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(b.BrandingThemes[n].CreatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalBrandingTheme(brandingThemeResponseBytes []byte) (*BrandingThemes, error) {
 	var brandingThemeResponse *BrandingThemes
 	err := json.Unmarshal(brandingThemeResponseBytes, &brandingThemeResponse)
@@ -50,21 +36,21 @@ func unmarshalBrandingTheme(brandingThemeResponseBytes []byte) (*BrandingThemes,
 		return nil, err
 	}
 
-	err = brandingThemeResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return brandingThemeResponse, err
 }
 
 //FindBrandingThemes will get all BrandingThemes.
 func FindBrandingThemes(provider *xerogolang.Provider, session goth.Session) (*BrandingThemes, error) {
+	return FindBrandingThemesContext(context.Background(), provider, session)
+}
+
+//FindBrandingThemesContext is like FindBrandingThemes but uses ctx for the request so that it can be cancelled or given a deadline
+func FindBrandingThemesContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*BrandingThemes, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	brandingThemeResponseBytes, err := provider.Find(session, "BrandingThemes", additionalHeaders, nil)
+	brandingThemeResponseBytes, err := provider.FindContext(ctx, session, "BrandingThemes", additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,185 @@
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/markbates/goth"
+)
+
+//Budget is an overall budget or a budget for one option of a tracking category in a Xero organisation
+type Budget struct {
+
+	// Xero generated unique identifier for the budget
+	BudgetID string `json:"BudgetID,omitempty"`
+
+	// OVERALL for the organisation's overall budget or TRACKING for a budget of a tracking option
+	Type BudgetType `json:"Type,omitempty"`
+
+	// The name of the budget
+	Description string `json:"Description,omitempty"`
+
+	// The amounts budgeted for each account. Only returned by FindBudget
+	BudgetLines []BudgetLine `json:"BudgetLines,omitempty"`
+
+	// The tracking option a TRACKING budget is for
+	Tracking []BudgetTracking `json:"Tracking,omitempty"`
+
+	// Last modified date UTC format
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty"`
+}
+
+//Budgets is a collection of Budgets
+type Budgets struct {
+	Budgets []Budget `json:"Budgets"`
+}
+
+//BudgetLine holds the amounts budgeted for an account in each period of a budget
+type BudgetLine struct {
+
+	// The Xero identifier of the account
+	AccountID string `json:"AccountID,omitempty"`
+
+	// The code of the account e.g. 200
+	AccountCode string `json:"AccountCode,omitempty"`
+
+	// The amount budgeted in each period
+	BudgetBalances []BudgetBalance `json:"BudgetBalances,omitempty"`
+}
+
+//BudgetBalance is the amount budgeted for an account in one period
+type BudgetBalance struct {
+
+	// The month the amount is budgeted for e.g. 2019-08
+	Period string `json:"Period,omitempty"`
+
+	// The amount budgeted
+	Amount Decimal `json:"Amount,omitempty"`
+
+	// The unit amount, when the budget was entered as a number of units
+	UnitAmount Decimal `json:"UnitAmount,omitempty"`
+
+	// Any notes on the amount
+	Notes string `json:"Notes,omitempty"`
+}
+
+//BudgetTracking is the tracking option of a TRACKING budget
+type BudgetTracking struct {
+
+	// The Xero identifier for the tracking category
+	TrackingCategoryID string `json:"TrackingCategoryID,omitempty"`
+
+	// The Xero identifier for the tracking option
+	TrackingOptionID string `json:"TrackingOptionID,omitempty"`
+
+	// The name of the tracking category e.g. Region
+	Name string `json:"Name,omitempty"`
+
+	// The name of the tracking option e.g. North
+	Option string `json:"Option,omitempty"`
+}
+
+//PeriodStart returns the first day of the month the balance is budgeted for
+func (b BudgetBalance) PeriodStart() (Date, error) {
+	month, err := time.Parse("2006-01", b.Period)
+	if err == nil {
+		return DateOf(month), nil
+	}
+
+	var date Date
+	err = date.UnmarshalText([]byte(b.Period))
+	if err != nil {
+		return Date{}, err
+	}
+	return NewDate(date.Year(), date.Month(), 1), nil
+}
+
+//Total returns the sum of the amounts budgeted for the account across every period
+func (b BudgetLine) Total() Decimal {
+	var total Decimal
+	for _, budgetBalance := range b.BudgetBalances {
+		total = total.Add(budgetBalance.Amount)
+	}
+	return total
+}
+
+//BudgetLine returns the line of the budget for the account with the given code
+func (b Budget) BudgetLine(accountCode string) (BudgetLine, bool) {
+	for _, budgetLine := range b.BudgetLines {
+		if budgetLine.AccountCode == accountCode {
+			return budgetLine, true
+		}
+	}
+	return BudgetLine{}, false
+}
+
+func unmarshalBudget(budgetResponseBytes []byte) (*Budgets, error) {
+	var budgetResponse *Budgets
+	err := json.Unmarshal(budgetResponseBytes, &budgetResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return budgetResponse, err
+}
+
+//budgetQuerystringParameters adds DateFrom and DateTo to a copy of querystringParameters when they are set
+func budgetQuerystringParameters(dateFrom Date, dateTo Date, querystringParameters map[string]string) map[string]string {
+	parameters := make(map[string]string, len(querystringParameters)+2)
+	for key, value := range querystringParameters {
+		parameters[key] = value
+	}
+	if !dateFrom.IsZero() {
+		parameters["DateFrom"] = dateFrom.String()
+	}
+	if !dateTo.IsZero() {
+		parameters["DateTo"] = dateTo.String()
+	}
+	if len(parameters) == 0 {
+		return nil
+	}
+	return parameters
+}
+
+//FindBudgets will get all budgets without their budget lines.
+//dateFrom and dateTo limit the budgets to those covering the period between them and are left out when they are zero.
+//additional querystringParameters such as IDs can be added as a map
+func FindBudgets(provider *xerogolang.Provider, session goth.Session, dateFrom Date, dateTo Date, querystringParameters map[string]string) (*Budgets, error) {
+	return FindBudgetsContext(context.Background(), provider, session, dateFrom, dateTo, querystringParameters)
+}
+
+//FindBudgetsContext is like FindBudgets but uses ctx for the request so that it can be cancelled or given a deadline
+func FindBudgetsContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, dateFrom Date, dateTo Date, querystringParameters map[string]string) (*Budgets, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	budgetResponseBytes, err := provider.FindContext(ctx, session, "Budgets", additionalHeaders, budgetQuerystringParameters(dateFrom, dateTo, querystringParameters))
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalBudget(budgetResponseBytes)
+}
+
+//FindBudget will get a single budget with its budget lines - budgetID must be a GUID for a budget.
+//dateFrom and dateTo limit the budget balances to the periods between them and are left out when they are zero
+func FindBudget(provider *xerogolang.Provider, session goth.Session, budgetID string, dateFrom Date, dateTo Date) (*Budgets, error) {
+	return FindBudgetContext(context.Background(), provider, session, budgetID, dateFrom, dateTo)
+}
+
+//FindBudgetContext is like FindBudget but uses ctx for the request so that it can be cancelled or given a deadline
+func FindBudgetContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, budgetID string, dateFrom Date, dateTo Date) (*Budgets, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	budgetResponseBytes, err := provider.FindContext(ctx, session, "Budgets/"+budgetID, additionalHeaders, budgetQuerystringParameters(dateFrom, dateTo, nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalBudget(budgetResponseBytes)
+}
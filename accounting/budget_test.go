@@ -0,0 +1,78 @@
+package accounting
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const budgetResponse = `{"Budgets": [{
+	"BudgetID": "budget-id",
+	"Type": "TRACKING",
+	"Description": "North budget",
+	"UpdatedDateUTC": "2019-08-01T02:03:04",
+	"Tracking": [{"TrackingCategoryID": "category-id", "TrackingOptionID": "option-id", "Name": "Region", "Option": "North"}],
+	"BudgetLines": [
+		{"AccountID": "account-id", "AccountCode": "200", "BudgetBalances": [{"Period": "2019-08", "Amount": 1000.10}, {"Period": "2019-09", "Amount": 1200.20, "Notes": "Spring sale"}]},
+		{"AccountID": "other-account-id", "AccountCode": "400", "BudgetBalances": [{"Period": "2019-08", "Amount": 50}]}
+	]
+}]}`
+
+func Test_FindBudget(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path+" "+r.URL.Query().Encode())
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, budgetResponse)
+	}))
+	defer ts.Close()
+	provider, session := testProvider(t, ts)
+
+	budgets, err := FindBudget(provider, session, "budget-id", NewDate(2019, time.August, 1), NewDate(2019, time.September, 30))
+	a.NoError(err)
+
+	budget := budgets.Budgets[0]
+	a.Equal(BudgetTypeTracking, budget.Type)
+	a.Equal("North", budget.Tracking[0].Option)
+
+	budgetLine, ok := budget.BudgetLine("200")
+	a.True(ok)
+	a.Equal("2200.3", budgetLine.Total().String())
+	a.Equal("Spring sale", budgetLine.BudgetBalances[1].Notes)
+	period, err := budgetLine.BudgetBalances[1].PeriodStart()
+	a.NoError(err)
+	a.Equal(NewDate(2019, time.September, 1), period)
+
+	_, ok = budget.BudgetLine("999")
+	a.False(ok)
+
+	_, err = FindBudgets(provider, session, Date{}, NewDate(2019, time.December, 31), map[string]string{"IDs": "budget-id"})
+	a.NoError(err)
+	_, err = FindBudgets(provider, session, Date{}, Date{}, nil)
+	a.NoError(err)
+
+	a.Equal([]string{
+		"/api.xro/2.0/Budgets/budget-id DateFrom=2019-08-01&DateTo=2019-09-30",
+		"/api.xro/2.0/Budgets DateTo=2019-12-31&IDs=budget-id",
+		"/api.xro/2.0/Budgets ",
+	}, requests)
+}
+
+func Test_BudgetBalance_PeriodStart(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	period, err := BudgetBalance{Period: "2019-08-15T00:00:00"}.PeriodStart()
+	a.NoError(err)
+	a.Equal(NewDate(2019, time.August, 1), period)
+
+	_, err = BudgetBalance{Period: "August"}.PeriodStart()
+	a.Error(err)
+}
@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -23,7 +23,7 @@ type Contact struct {
 	AccountNumber string `json:"AccountNumber,omitempty" xml:"AccountNumber,omitempty"`
 
 	// Current status of a contact – see contact status types
-	ContactStatus string `json:"ContactStatus,omitempty" xml:"ContactStatus,omitempty"`
+	ContactStatus ContactStatus `json:"ContactStatus,omitempty" xml:"ContactStatus,omitempty"`
 
 	// Full name of contact/organisation (max length = 255)
 	Name string `json:"Name,omitempty" xml:"Name,omitempty"`
@@ -92,7 +92,7 @@ type Contact struct {
 	TrackingCategoryOption string `json:"TrackingCategoryOption,omitempty" xml:"TrackingCategoryOption,omitempty"`
 
 	// UTC timestamp of last update to contact
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"-"`
 
 	// Displays which contact groups a contact is included in
 	ContactGroups *[]ContactGroup `json:"ContactGroups,omitempty" xml:"ContactGroups>ContactGroup,omitempty"`
@@ -104,7 +104,7 @@ type Contact struct {
 	BatchPayments BatchPayment `json:"BatchPayments,omitempty" xml:"-"`
 
 	// The default discount rate for the contact (read only)
-	Discount float64 `json:"Discount,omitempty" xml:"-"`
+	Discount Decimal `json:"Discount,omitempty" xml:"-"`
 
 	// The raw AccountsReceivable(sales Contacts) and AccountsPayable(bills) outstanding and overdue amounts, not converted to base currency (read only)
 	Balances Balances `json:"Balances,omitempty" xml:"-"`
@@ -128,22 +128,8 @@ type Balances struct {
 //Balance is the raw AccountsReceivable(sales invoices) and AccountsPayable(bills)
 //outstanding and overdue amounts, not converted to base currency
 type Balance struct {
-	Outstanding float64 `json:"Outstanding,omitempty" xml:"Outstanding,omitempty"`
-	Overdue     float64 `json:"Overdue,omitempty" xml:"Overdue,omitempty"`
-}
-
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (c *Contacts) convertDates() error {
-	var err error
-	for n := len(c.Contacts) - 1; n >= 0; n-- {
-		c.Contacts[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(c.Contacts[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+	Outstanding Decimal `json:"Outstanding,omitempty" xml:"Outstanding,omitempty"`
+	Overdue     Decimal `json:"Overdue,omitempty" xml:"Overdue,omitempty"`
 }
 
 func unmarshalContact(contactResponseBytes []byte) (*Contacts, error) {
@@ -153,27 +139,42 @@ func unmarshalContact(contactResponseBytes []byte) (*Contacts, error) {
 		return nil, err
 	}
 
-	err = contactResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return contactResponse, err
 }
 
+//Validate checks the contacts for problems Xero would reject them for, without sending them.
+//Create and Update call it before making a request
+func (c *Contacts) Validate() error {
+	v := &validator{}
+	for n, contact := range c.Contacts {
+		v.check(field("Contacts", n, "ContactStatus"), contact.ContactStatus.Validate())
+	}
+	return v.err()
+}
+
 //Create will create Contacts given an Contacts struct
 func (c *Contacts) Create(provider *xerogolang.Provider, session goth.Session) (*Contacts, error) {
+	return c.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (c *Contacts) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Contacts, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body, err := xml.MarshalIndent(c, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	contactResponseBytes, err := provider.Create(session, "Contacts", additionalHeaders, body)
+	contactResponseBytes, err := provider.CreateContext(ctx, session, "Contacts", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -184,17 +185,27 @@ func (c *Contacts) Create(provider *xerogolang.Provider, session goth.Session) (
 //Update will update a Contact given a Contacts struct
 //This will only handle single Contact - you cannot update multiple Contacts in a single call
 func (c *Contacts) Update(provider *xerogolang.Provider, session goth.Session) (*Contacts, error) {
+	return c.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (c *Contacts) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Contacts, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body, err := xml.MarshalIndent(c, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	contactResponseBytes, err := provider.Update(session, "Contacts/"+c.Contacts[0].ContactID, additionalHeaders, body)
+	contactResponseBytes, err := provider.UpdateContext(ctx, session, "Contacts/"+c.Contacts[0].ContactID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -207,6 +218,11 @@ func (c *Contacts) Update(provider *xerogolang.Provider, session goth.Session) (
 //If you need details then then add a 'page' querystringParameter and get 100 Contacts at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindContactsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Contacts, error) {
+	return FindContactsModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindContactsModifiedSinceContext is like FindContactsModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindContactsModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Contacts, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -215,7 +231,7 @@ func FindContactsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	contactResponseBytes, err := provider.Find(session, "Contacts", additionalHeaders, querystringParameters)
+	contactResponseBytes, err := provider.FindContext(ctx, session, "Contacts", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -227,16 +243,36 @@ func FindContactsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 //If you need details then then add a 'page' querystringParameter and get 100 Contacts at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindContacts(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Contacts, error) {
-	return FindContactsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindContactsContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindContactsContext is like FindContacts but uses ctx for the request so that it can be cancelled or given a deadline
+func FindContactsContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Contacts, error) {
+	return FindContactsModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//IterateContacts returns an Iterator over every contact matching querystringParameters
+func IterateContacts(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) *Iterator[Contact] {
+	return iterate(ctx, provider, session, querystringParameters, FindContactsContext, func(contacts *Contacts) []Contact { return contacts.Contacts })
+}
+
+//ForEachContact calls fn with every contact matching querystringParameters, stopping at the first error fn returns
+func ForEachContact(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string, fn func(contact Contact) error) error {
+	return ForEach(IterateContacts(ctx, provider, session, querystringParameters), fn)
 }
 
 //FindContact will get a single Contact - ContactID can be a GUID for an Contact or an Contact number
 func FindContact(provider *xerogolang.Provider, session goth.Session, contactID string) (*Contacts, error) {
+	return FindContactContext(context.Background(), provider, session, contactID)
+}
+
+//FindContactContext is like FindContact but uses ctx for the request so that it can be cancelled or given a deadline
+func FindContactContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, contactID string) (*Contacts, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	contactResponseBytes, err := provider.Find(session, "Contacts/"+contactID, additionalHeaders, nil)
+	contactResponseBytes, err := provider.FindContext(ctx, session, "Contacts/"+contactID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -246,6 +282,11 @@ func FindContact(provider *xerogolang.Provider, session goth.Session, contactID
 
 //AddToContactGroup will add a collection of Contacts to a supplied contactGroupID
 func (c *Contacts) AddToContactGroup(provider *xerogolang.Provider, session goth.Session, contactGroupID string) (*Contacts, error) {
+	return c.AddToContactGroupContext(context.Background(), provider, session, contactGroupID)
+}
+
+//AddToContactGroupContext is like AddToContactGroup but uses ctx for the request so that it can be cancelled or given a deadline
+func (c *Contacts) AddToContactGroupContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, contactGroupID string) (*Contacts, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -265,7 +306,7 @@ func (c *Contacts) AddToContactGroup(provider *xerogolang.Provider, session goth
 		return nil, err
 	}
 
-	contactResponseBytes, err := provider.Update(session, "ContactGroups/"+contactGroupID+"/Contacts", additionalHeaders, body)
+	contactResponseBytes, err := provider.UpdateContext(ctx, session, "ContactGroups/"+contactGroupID+"/Contacts", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -275,11 +316,16 @@ func (c *Contacts) AddToContactGroup(provider *xerogolang.Provider, session goth
 
 //RemoveFromContactGroup will remove a Contact from a supplied contactGroupID - must be done one at a time.
 func (c *Contacts) RemoveFromContactGroup(provider *xerogolang.Provider, session goth.Session, contactGroupID string) (*Contacts, error) {
+	return c.RemoveFromContactGroupContext(context.Background(), provider, session, contactGroupID)
+}
+
+//RemoveFromContactGroupContext is like RemoveFromContactGroup but uses ctx for the request so that it can be cancelled or given a deadline
+func (c *Contacts) RemoveFromContactGroupContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, contactGroupID string) (*Contacts, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	contactResponseBytes, err := provider.Remove(session, "ContactGroups/"+contactGroupID+"/Contacts/"+c.Contacts[0].ContactID, additionalHeaders)
+	contactResponseBytes, err := provider.RemoveContext(ctx, session, "ContactGroups/"+contactGroupID+"/Contacts/"+c.Contacts[0].ContactID, additionalHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -1,12 +1,13 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
+	"io"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -15,7 +16,7 @@ import (
 type CreditNote struct {
 
 	// See Credit Note Types
-	Type string `json:"Type,omitempty" xml:"Type,omitempty"`
+	Type CreditNoteType `json:"Type,omitempty" xml:"Type,omitempty"`
 
 	// See Contacts
 	Contact Contact `json:"Contact" xml:"Contact"`
@@ -23,34 +24,34 @@ type CreditNote struct {
 	// The date the credit note is issued YYYY-MM-DD.
 	// If the Date element is not specified then it will default
 	// to the current date based on the timezone setting of the organisation
-	Date string `json:"DateString,omitempty" xml:"Date,omitempty"`
+	Date Date `json:"DateString,omitempty" xml:"Date,omitempty"`
 
 	// See Credit Note Status Codes
-	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
+	Status CreditNoteStatus `json:"Status,omitempty" xml:"Status,omitempty"`
 
 	// See Invoice Line Amount Types
-	LineAmountTypes string `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
 
 	// See Invoice Line Items
 	LineItems []LineItem `json:"LineItems,omitempty" xml:"LineItems>LineItem,omitempty"`
 
 	// The subtotal of the credit note excluding taxes
-	SubTotal float64 `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
+	SubTotal Decimal `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
 
 	// The total tax on the credit note
-	TotalTax float64 `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
+	TotalTax Decimal `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
 
 	// The total of the Credit Note(subtotal + total tax)
-	Total float64 `json:"Total,omitempty" xml:"Total,omitempty"`
+	Total Decimal `json:"Total,omitempty" xml:"Total,omitempty"`
 
 	// UTC timestamp of last update to the credit note
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"-"`
 
 	// Currency used for the Credit Note
 	CurrencyCode string `json:"CurrencyCode,omitempty" xml:"CurrencyCode,omitempty"`
 
 	// Date when credit note was fully paid(UTC format)
-	FullyPaidOnDate string `json:"FullyPaidOnDate,omitempty" xml:"-"`
+	FullyPaidOnDate Date `json:"FullyPaidOnDate,omitempty" xml:"-"`
 
 	// Xero generated unique identifier
 	CreditNoteID string `json:"CreditNoteID,omitempty" xml:"CreditNoteID,omitempty"`
@@ -65,10 +66,10 @@ type CreditNote struct {
 	SentToContact bool `json:"SentToContact,omitempty" xml:"SentToContact,omitempty"`
 
 	// The currency rate for a multicurrency invoice. If no rate is specified, the XE.com day rate is used
-	CurrencyRate float64 `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
+	CurrencyRate Decimal `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
 
 	// The remaining credit balance on the Credit Note
-	RemainingCredit float64 `json:"RemainingCredit,omitempty" xml:"-"`
+	RemainingCredit Decimal `json:"RemainingCredit,omitempty" xml:"-"`
 
 	// See Allocations
 	Allocations *[]Allocation `json:"Allocations,omitempty" xml:"-"`
@@ -85,20 +86,6 @@ type CreditNotes struct {
 	CreditNotes []CreditNote `json:"CreditNotes" xml:"CreditNote"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (c *CreditNotes) convertDates() error {
-	var err error
-	for n := len(c.CreditNotes) - 1; n >= 0; n-- {
-		c.CreditNotes[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(c.CreditNotes[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalCreditNote(creditNoteResponseBytes []byte) (*CreditNotes, error) {
 	var creditNoteResponse *CreditNotes
 	err := json.Unmarshal(creditNoteResponseBytes, &creditNoteResponse)
@@ -106,27 +93,56 @@ func unmarshalCreditNote(creditNoteResponseBytes []byte) (*CreditNotes, error) {
 		return nil, err
 	}
 
-	err = creditNoteResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return creditNoteResponse, err
 }
 
+//Validate checks the credit notes for problems Xero would reject them for, without sending them.
+//Fields that are only needed to create a credit note are checked when it has no CreditNoteID.
+//Create and Update call it before making a request
+func (c *CreditNotes) Validate() error {
+	v := &validator{}
+	for n, creditNote := range c.CreditNotes {
+		v.check(field("CreditNotes", n, "Type"), creditNote.Type.Validate())
+		v.check(field("CreditNotes", n, "Status"), creditNote.Status.Validate())
+		v.check(field("CreditNotes", n, "LineAmountTypes"), creditNote.LineAmountTypes.Validate())
+		v.maxLength(field("CreditNotes", n, "CreditNoteNumber"), creditNote.CreditNoteNumber, 255)
+		v.maxLength(field("CreditNotes", n, "Reference"), creditNote.Reference, 255)
+		v.lineItems(field("CreditNotes", n, "LineItems"), creditNote.LineItems, creditNote.Type == "" || creditNote.Type == CreditNoteTypeAccRecCredit)
+
+		if creditNote.CreditNoteID == "" {
+			v.required(field("CreditNotes", n, "Type"), creditNote.Type != "")
+			v.contact(field("CreditNotes", n, "Contact"), creditNote.Contact)
+			if creditNote.Status == CreditNoteStatusSubmitted || creditNote.Status == CreditNoteStatusAuthorised {
+				v.required(field("CreditNotes", n, "LineItems"), len(creditNote.LineItems) > 0)
+			}
+		}
+	}
+	return v.err()
+}
+
 //Create will create creditNotes given an CreditNotes struct
 func (c *CreditNotes) Create(provider *xerogolang.Provider, session goth.Session) (*CreditNotes, error) {
+	return c.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (c *CreditNotes) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*CreditNotes, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body, err := xml.MarshalIndent(c, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	creditNoteResponseBytes, err := provider.Create(session, "CreditNotes", additionalHeaders, body)
+	creditNoteResponseBytes, err := provider.CreateContext(ctx, session, "CreditNotes", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -137,17 +153,27 @@ func (c *CreditNotes) Create(provider *xerogolang.Provider, session goth.Session
 //Update will update an creditNote given an CreditNotes struct
 //This will only handle single creditNote - you cannot update multiple creditNotes in a single call
 func (c *CreditNotes) Update(provider *xerogolang.Provider, session goth.Session) (*CreditNotes, error) {
+	return c.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (c *CreditNotes) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*CreditNotes, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body, err := xml.MarshalIndent(c, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	creditNoteResponseBytes, err := provider.Update(session, "CreditNotes/"+c.CreditNotes[0].CreditNoteID, additionalHeaders, body)
+	creditNoteResponseBytes, err := provider.UpdateContext(ctx, session, "CreditNotes/"+c.CreditNotes[0].CreditNoteID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -160,6 +186,11 @@ func (c *CreditNotes) Update(provider *xerogolang.Provider, session goth.Session
 //If you need details then then add a 'page' querystringParameter and get 100 Credit Notes at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindCreditNotesModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*CreditNotes, error) {
+	return FindCreditNotesModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindCreditNotesModifiedSinceContext is like FindCreditNotesModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindCreditNotesModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*CreditNotes, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -168,7 +199,7 @@ func FindCreditNotesModifiedSince(provider *xerogolang.Provider, session goth.Se
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	creditNoteResponseBytes, err := provider.Find(session, "CreditNotes", additionalHeaders, querystringParameters)
+	creditNoteResponseBytes, err := provider.FindContext(ctx, session, "CreditNotes", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -180,16 +211,36 @@ func FindCreditNotesModifiedSince(provider *xerogolang.Provider, session goth.Se
 //If you need details then then add a 'page' querystringParameter and get 100 Credit Notes at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindCreditNotes(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*CreditNotes, error) {
-	return FindCreditNotesModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindCreditNotesContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindCreditNotesContext is like FindCreditNotes but uses ctx for the request so that it can be cancelled or given a deadline
+func FindCreditNotesContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*CreditNotes, error) {
+	return FindCreditNotesModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//IterateCreditNotes returns an Iterator over every credit note matching querystringParameters
+func IterateCreditNotes(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) *Iterator[CreditNote] {
+	return iterate(ctx, provider, session, querystringParameters, FindCreditNotesContext, func(creditNotes *CreditNotes) []CreditNote { return creditNotes.CreditNotes })
+}
+
+//ForEachCreditNote calls fn with every credit note matching querystringParameters, stopping at the first error fn returns
+func ForEachCreditNote(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string, fn func(creditNote CreditNote) error) error {
+	return ForEach(IterateCreditNotes(ctx, provider, session, querystringParameters), fn)
 }
 
 //FindCreditNote will get a single creditNote - creditNoteID can be a GUID for a creditNote or a creditNote number
 func FindCreditNote(provider *xerogolang.Provider, session goth.Session, creditNoteID string) (*CreditNotes, error) {
+	return FindCreditNoteContext(context.Background(), provider, session, creditNoteID)
+}
+
+//FindCreditNoteContext is like FindCreditNote but uses ctx for the request so that it can be cancelled or given a deadline
+func FindCreditNoteContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, creditNoteID string) (*CreditNotes, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	creditNoteResponseBytes, err := provider.Find(session, "CreditNotes/"+creditNoteID, additionalHeaders, nil)
+	creditNoteResponseBytes, err := provider.FindContext(ctx, session, "CreditNotes/"+creditNoteID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -197,22 +248,37 @@ func FindCreditNote(provider *xerogolang.Provider, session goth.Session, creditN
 	return unmarshalCreditNote(creditNoteResponseBytes)
 }
 
+//FindCreditNotePDF gets the PDF of a single credit note as Xero renders it - creditNoteID can be a GUID for a credit note or a credit note number.
+//The PDF is streamed rather than read into memory and the caller must close the returned reader
+func FindCreditNotePDF(provider *xerogolang.Provider, session goth.Session, creditNoteID string) (io.ReadCloser, error) {
+	return FindCreditNotePDFContext(context.Background(), provider, session, creditNoteID)
+}
+
+//FindCreditNotePDFContext is like FindCreditNotePDF but uses ctx for the request so that it can be cancelled or given a deadline
+func FindCreditNotePDFContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, creditNoteID string) (io.ReadCloser, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/pdf",
+	}
+
+	return provider.FindStreamContext(ctx, session, "CreditNotes/"+creditNoteID, additionalHeaders, nil)
+}
+
 //GenerateExampleCreditNote Creates an Example creditNote
 func GenerateExampleCreditNote() *CreditNotes {
 	lineItem := LineItem{
 		Description: "Refund Importing & Exporting Services",
-		Quantity:    1.00,
-		UnitAmount:  395.00,
+		Quantity:    MustParseDecimal("1.00"),
+		UnitAmount:  MustParseDecimal("395.00"),
 		AccountCode: "200",
 	}
 
 	creditNote := CreditNote{
-		Type: "ACCRECCREDIT",
+		Type: CreditNoteTypeAccRecCredit,
 		Contact: Contact{
 			Name: "George Costanza",
 		},
-		Date:            helpers.TodayRFC3339(),
-		LineAmountTypes: "Exclusive",
+		Date:            Today(),
+		LineAmountTypes: LineAmountExclusive,
 		LineItems:       []LineItem{},
 	}
 
@@ -1,6 +1,7 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/XeroAPI/xerogolang"
@@ -34,11 +35,16 @@ func unmarshalCurrencies(currencyResponseBytes []byte) (*Currencies, error) {
 
 //FindCurrencies will get all currencies
 func FindCurrencies(provider *xerogolang.Provider, session goth.Session) (*Currencies, error) {
+	return FindCurrenciesContext(context.Background(), provider, session)
+}
+
+//FindCurrenciesContext is like FindCurrencies but uses ctx for the request so that it can be cancelled or given a deadline
+func FindCurrenciesContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Currencies, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	currencyResponseBytes, err := provider.Find(session, "Currencies", additionalHeaders, nil)
+	currencyResponseBytes, err := provider.FindContext(ctx, session, "Currencies", additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
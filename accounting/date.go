@@ -0,0 +1,213 @@
+package accounting
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/XeroAPI/xerogolang/helpers"
+)
+
+//Date is a calendar date with no time of day, such as the date an invoice is due.
+//It reads the .Net JSON dates and RFC3339 strings returned by Xero and is written as YYYY-MM-DD.
+//The zero Date is left out of request bodies
+type Date struct {
+	time.Time
+}
+
+//DateTime is an instant, such as when a record was last updated.
+//It reads the .Net JSON dates and RFC3339 strings returned by Xero and is written in RFC3339 format in UTC.
+//The zero DateTime is left out of request bodies
+type DateTime struct {
+	time.Time
+}
+
+const dateFormat = "2006-01-02"
+
+//NewDate returns the Date for the given year, month and day
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+//DateOf returns the date of t in t's location
+func DateOf(t time.Time) Date {
+	if t.IsZero() {
+		return Date{}
+	}
+	return NewDate(t.Year(), t.Month(), t.Day())
+}
+
+//Today returns the current date in the local time zone
+func Today() Date {
+	return DateOf(time.Now())
+}
+
+//NewDateTime returns a DateTime for t
+func NewDateTime(t time.Time) DateTime {
+	return DateTime{t}
+}
+
+//parseXeroTime reads the date formats used by Xero. Times without an offset are taken to be UTC
+func parseXeroTime(value string) (time.Time, error) {
+	if strings.HasPrefix(value, "/Date(") {
+		return helpers.ParseDotNetJSONTime(value)
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", dateFormat} {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Could not parse %q as a date", value)
+}
+
+//String returns the date as YYYY-MM-DD, or an empty string for the zero Date
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(dateFormat)
+}
+
+//Before reports whether d is before other
+func (d Date) Before(other Date) bool {
+	return d.Time.Before(other.Time)
+}
+
+//After reports whether d is after other
+func (d Date) After(other Date) bool {
+	return d.Time.After(other.Time)
+}
+
+//Equal reports whether d and other are the same date
+func (d Date) Equal(other Date) bool {
+	return d.Time.Equal(other.Time)
+}
+
+//Compare returns -1 if d is before other, +1 if it is after and 0 if they are the same date
+func (d Date) Compare(other Date) int {
+	return d.Time.Compare(other.Time)
+}
+
+//MarshalText writes the date as YYYY-MM-DD
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+//UnmarshalText reads any of the date formats used by Xero. An empty string is the zero Date
+func (d *Date) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = Date{}
+		return nil
+	}
+	t, err := parseXeroTime(string(text))
+	if err != nil {
+		return err
+	}
+	*d = DateOf(t)
+	return nil
+}
+
+//MarshalJSON writes the date as a YYYY-MM-DD string, or null for the zero Date
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+//UnmarshalJSON reads a date string in any of the formats used by Xero. null is the zero Date
+func (d *Date) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, d.UnmarshalText)
+}
+
+//MarshalXML writes the date as YYYY-MM-DD. The element is left out for the zero Date
+func (d Date) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	if d.IsZero() {
+		return nil
+	}
+	return encoder.EncodeElement(d.String(), start)
+}
+
+//String returns the time in RFC3339 format in UTC, or an empty string for the zero DateTime
+func (d DateTime) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.UTC().Format(time.RFC3339)
+}
+
+//Before reports whether d is before other
+func (d DateTime) Before(other DateTime) bool {
+	return d.Time.Before(other.Time)
+}
+
+//After reports whether d is after other
+func (d DateTime) After(other DateTime) bool {
+	return d.Time.After(other.Time)
+}
+
+//Equal reports whether d and other are the same instant
+func (d DateTime) Equal(other DateTime) bool {
+	return d.Time.Equal(other.Time)
+}
+
+//Compare returns -1 if d is before other, +1 if it is after and 0 if they are the same instant
+func (d DateTime) Compare(other DateTime) int {
+	return d.Time.Compare(other.Time)
+}
+
+//MarshalText writes the time in RFC3339 format in UTC
+func (d DateTime) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+//UnmarshalText reads any of the date formats used by Xero. An empty string is the zero DateTime
+func (d *DateTime) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = DateTime{}
+		return nil
+	}
+	t, err := parseXeroTime(string(text))
+	if err != nil {
+		return err
+	}
+	*d = DateTime{t}
+	return nil
+}
+
+//MarshalJSON writes the time as an RFC3339 string, or null for the zero DateTime
+func (d DateTime) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+//UnmarshalJSON reads a date string in any of the formats used by Xero. null is the zero DateTime
+func (d *DateTime) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, d.UnmarshalText)
+}
+
+//MarshalXML writes the time in RFC3339 format in UTC. The element is left out for the zero DateTime
+func (d DateTime) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	if d.IsZero() {
+		return nil
+	}
+	return encoder.EncodeElement(d.String(), start)
+}
+
+//unmarshalJSONText passes the contents of a JSON string to unmarshalText, treating null as an empty string
+func unmarshalJSONText(data []byte, unmarshalText func(text []byte) error) error {
+	if string(data) == "null" {
+		return unmarshalText(nil)
+	}
+	var text string
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return err
+	}
+	return unmarshalText([]byte(text))
+}
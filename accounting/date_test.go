@@ -0,0 +1,78 @@
+package accounting
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Date_Unmarshal(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var invoices Invoices
+	err := json.Unmarshal([]byte(`{"Invoices": [{
+		"DateString": "2018-06-30T00:00:00",
+		"DueDateString": "/Date(1533081600000+0000)/",
+		"ExpectedPaymentDate": "2018-08-15",
+		"UpdatedDateUTC": "/Date(1530349263513+0000)/",
+		"FullyPaidOnDate": null
+	}]}`), &invoices)
+	a.NoError(err)
+
+	invoice := invoices.Invoices[0]
+	a.Equal(NewDate(2018, time.June, 30), invoice.Date)
+	a.Equal(NewDate(2018, time.August, 1), invoice.DueDate)
+	a.Equal("2018-08-15", invoice.ExpectedPaymentDate.String())
+	a.True(invoice.FullyPaidOnDate.IsZero())
+	a.True(invoice.PlannedPaymentDate.IsZero())
+	a.Equal("2018-06-30T09:01:03Z", invoice.UpdatedDateUTC.String())
+	a.True(invoice.Date.Before(invoice.DueDate))
+
+	var journal Journal
+	a.Error(json.Unmarshal([]byte(`{"JournalDate": "30 June 2018"}`), &journal))
+}
+
+func Test_Date_MarshalXML(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	invoice := Invoice{
+		Type:           "ACCREC",
+		Date:           DateOf(time.Date(2018, time.June, 30, 23, 30, 0, 0, time.UTC)),
+		UpdatedDateUTC: NewDateTime(time.Now()),
+	}
+	body, err := xml.Marshal(invoice)
+	a.NoError(err)
+	a.Contains(string(body), "<Date>2018-06-30</Date>")
+	a.NotContains(string(body), "DueDate")
+	a.NotContains(string(body), "UpdatedDateUTC")
+
+	var decoded Schedule
+	err = xml.Unmarshal([]byte("<Schedule><StartDate>2018-07-01T00:00:00</StartDate></Schedule>"), &decoded)
+	a.NoError(err)
+	a.Equal(NewDate(2018, time.July, 1), decoded.StartDate)
+}
+
+func Test_Date_Sort(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	dates := []Date{NewDate(2018, time.March, 1), NewDate(2017, time.December, 25), NewDate(2018, time.January, 1)}
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+	a.Equal([]Date{NewDate(2017, time.December, 25), NewDate(2018, time.January, 1), NewDate(2018, time.March, 1)}, dates)
+	a.Equal(0, DateOf(time.Date(2018, time.March, 1, 12, 0, 0, 0, time.UTC)).Compare(dates[2]))
+
+	body, err := json.Marshal(struct {
+		Date     Date
+		DateTime DateTime
+	}{Date: dates[0]})
+	a.NoError(err)
+	a.Equal(`{"Date":"2017-12-25","DateTime":null}`, string(body))
+}
@@ -0,0 +1,382 @@
+package accounting
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//DecimalPlaces is the precision a Decimal holds. It covers the 4 decimal places of unit amounts
+//and quantities when unitdp=4 is used and the 6 decimal places of currency rates
+const DecimalPlaces = 6
+
+//decimalScale is 10^DecimalPlaces
+const decimalScale = 1000000
+
+//ErrDecimalOverflow is returned when a number is too large for a Decimal, which holds about ±9.2 trillion
+var ErrDecimalOverflow = errors.New("the number is too large for a decimal")
+
+//Decimal is an exact fixed-point number used for amounts, quantities and rates so that sums don't drift
+//the way float64 does. It holds DecimalPlaces decimal places and rounds anything beyond that half away from zero.
+//Compare Decimals with Equal or Cmp rather than ==. The zero Decimal is left out of request bodies.
+//Add, Sub, Mul and Neg panic if the result is too large; use CheckedAdd, CheckedSub and CheckedMul when it may be
+type Decimal struct {
+	//units is the value multiplied by 10^DecimalPlaces
+	units int64
+}
+
+//NewDecimal returns value / 10^places e.g. NewDecimal(39550, 2) is 395.50.
+//It panics if the result is too large for a Decimal
+func NewDecimal(value int64, places int) Decimal {
+	return mustDecimal(decimalFromRat(new(big.Rat).SetFrac(big.NewInt(value), pow10(places))))
+}
+
+//NewDecimalFromFloat returns the Decimal closest to f.
+//It panics if f is NaN, infinite or too large for a Decimal
+func NewDecimalFromFloat(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		panic(fmt.Sprintf("%v is not a decimal number", f))
+	}
+	return mustDecimal(ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64)))
+}
+
+//ParseDecimal reads a plain decimal number such as "1234", "-1234.5678" or "+0.5".
+//Exponents, fractions and hexadecimal are not accepted
+func ParseDecimal(s string) (Decimal, error) {
+	digits, ok := plainDecimal(strings.TrimSpace(s))
+	if !ok {
+		return Decimal{}, fmt.Errorf("Could not parse %q as a decimal", s)
+	}
+	value, _ := new(big.Rat).SetString(digits)
+	d, err := decimalFromRat(value)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("Could not parse %q as a decimal: %w", s, err)
+	}
+	return d, nil
+}
+
+//plainDecimal checks that s is an optional sign, digits and an optional fraction. It returns s without the digits
+//that can't change the result so that very long input is cheap to convert: leading zeros, fraction digits after
+//the one that decides rounding, and integer digits beyond the 20 that already make it too large for a Decimal
+func plainDecimal(s string) (string, bool) {
+	sign := ""
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		sign, s = s[:1], s[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(s, ".")
+	if !isDigits(integer) || (hasFraction && !isDigits(fraction)) {
+		return "", false
+	}
+
+	integer = strings.TrimLeft(integer, "0")
+	if integer == "" {
+		integer = "0"
+	}
+	if len(integer) > 20 {
+		integer = integer[:20]
+	}
+	if len(fraction) > DecimalPlaces+1 {
+		fraction = fraction[:DecimalPlaces+1]
+	}
+	if fraction == "" {
+		return sign + integer, true
+	}
+	return sign + integer + "." + fraction, true
+}
+
+//isDigits reports whether s is one or more of 0-9
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for n := 0; n < len(s); n++ {
+		if s[n] < '0' || s[n] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+//MustParseDecimal is like ParseDecimal but panics if s is not a decimal number. It is meant for constants
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+//pow10 returns 10^n as a big.Int
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+//decimalFromRat rounds value to DecimalPlaces, half away from zero
+func decimalFromRat(value *big.Rat) (Decimal, error) {
+	scaled := new(big.Rat).Mul(value, new(big.Rat).SetInt64(decimalScale))
+	return decimalFromUnits(roundHalfAwayFromZero(scaled.Num(), scaled.Denom()))
+}
+
+//decimalFromUnits returns the Decimal with the given units, or ErrDecimalOverflow if they don't fit
+func decimalFromUnits(units *big.Int) (Decimal, error) {
+	if !units.IsInt64() {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	return Decimal{units: units.Int64()}, nil
+}
+
+//mustDecimal panics if err is set. It is used by the methods that have no error result
+func mustDecimal(d Decimal, err error) Decimal {
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+//roundHalfAwayFromZero divides numerator by denominator, rounding halves away from zero
+func roundHalfAwayFromZero(numerator *big.Int, denominator *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	twiceRemainder := new(big.Int).Abs(remainder)
+	twiceRemainder.Lsh(twiceRemainder, 1)
+	if twiceRemainder.Cmp(new(big.Int).Abs(denominator)) >= 0 {
+		if numerator.Sign()*denominator.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return quotient
+}
+
+//Add returns d + other. It panics if the result is too large - see CheckedAdd
+func (d Decimal) Add(other Decimal) Decimal {
+	return mustDecimal(d.CheckedAdd(other))
+}
+
+//CheckedAdd returns d + other, or ErrDecimalOverflow if the result is too large
+func (d Decimal) CheckedAdd(other Decimal) (Decimal, error) {
+	units := d.units + other.units
+	if (other.units > 0 && units < d.units) || (other.units < 0 && units > d.units) {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	return Decimal{units: units}, nil
+}
+
+//Sub returns d - other. It panics if the result is too large - see CheckedSub
+func (d Decimal) Sub(other Decimal) Decimal {
+	return mustDecimal(d.CheckedSub(other))
+}
+
+//CheckedSub returns d - other, or ErrDecimalOverflow if the result is too large
+func (d Decimal) CheckedSub(other Decimal) (Decimal, error) {
+	units := d.units - other.units
+	if (other.units > 0 && units > d.units) || (other.units < 0 && units < d.units) {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	return Decimal{units: units}, nil
+}
+
+//Mul returns d * other rounded to DecimalPlaces. It panics if the result is too large - see CheckedMul
+func (d Decimal) Mul(other Decimal) Decimal {
+	return mustDecimal(d.CheckedMul(other))
+}
+
+//CheckedMul returns d * other rounded to DecimalPlaces, or ErrDecimalOverflow if the result is too large
+func (d Decimal) CheckedMul(other Decimal) (Decimal, error) {
+	product := new(big.Int).Mul(big.NewInt(d.units), big.NewInt(other.units))
+	return decimalFromUnits(roundHalfAwayFromZero(product, big.NewInt(decimalScale)))
+}
+
+//Div returns d / other rounded to DecimalPlaces, or an error if other is zero or the result is too large
+func (d Decimal) Div(other Decimal) (Decimal, error) {
+	if other.IsZero() {
+		return Decimal{}, errors.New("division by zero")
+	}
+	numerator := new(big.Int).Mul(big.NewInt(d.units), big.NewInt(decimalScale))
+	return decimalFromUnits(roundHalfAwayFromZero(numerator, big.NewInt(other.units)))
+}
+
+//Neg returns -d. It panics if the result is too large, which only happens for the most negative Decimal
+func (d Decimal) Neg() Decimal {
+	return mustDecimal(Decimal{}.CheckedSub(d))
+}
+
+//Abs returns the absolute value of d
+func (d Decimal) Abs() Decimal {
+	if d.units < 0 {
+		return d.Neg()
+	}
+	return d
+}
+
+//Sign returns -1, 0 or +1 depending on whether d is negative, zero or positive
+func (d Decimal) Sign() int {
+	switch {
+	case d.units < 0:
+		return -1
+	case d.units > 0:
+		return 1
+	}
+	return 0
+}
+
+//IsZero reports whether d is zero
+func (d Decimal) IsZero() bool {
+	return d.units == 0
+}
+
+//Cmp returns -1 if d is less than other, +1 if it is greater and 0 if they are equal
+func (d Decimal) Cmp(other Decimal) int {
+	switch {
+	case d.units < other.units:
+		return -1
+	case d.units > other.units:
+		return 1
+	}
+	return 0
+}
+
+//Equal reports whether d and other are the same number
+func (d Decimal) Equal(other Decimal) bool {
+	return d.units == other.units
+}
+
+//Round returns d rounded to places decimal places, half away from zero.
+//It panics if rounding up makes the result too large
+func (d Decimal) Round(places int) Decimal {
+	if places >= DecimalPlaces {
+		return d
+	}
+	if places < 0 {
+		places = 0
+	}
+	step := pow10(DecimalPlaces - places)
+	rounded := d.roundedUnits(step)
+	return mustDecimal(decimalFromUnits(rounded.Mul(rounded, step)))
+}
+
+//roundedUnits returns d divided by step and rounded half away from zero
+func (d Decimal) roundedUnits(step *big.Int) *big.Int {
+	return roundHalfAwayFromZero(big.NewInt(d.units), step)
+}
+
+//RoundToCurrency rounds d to the number of decimal places used by the currency e.g. 2 for NZD, 0 for JPY
+func (d Decimal) RoundToCurrency(currencyCode string) Decimal {
+	return d.Round(CurrencyDecimalPlaces(currencyCode))
+}
+
+//currencyDecimalPlaces lists the ISO 4217 currencies that don't have 2 decimal places
+var currencyDecimalPlaces = map[string]int{
+	"BHD": 3, "BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3, "ISK": 0, "JOD": 3, "JPY": 0, "KMF": 0,
+	"KRW": 0, "KWD": 3, "LYD": 3, "OMR": 3, "PYG": 0, "RWF": 0, "TND": 3, "UGX": 0, "VND": 0, "VUV": 0,
+	"XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+//CurrencyDecimalPlaces returns the number of decimal places amounts in the currency are given to
+func CurrencyDecimalPlaces(currencyCode string) int {
+	if places, ok := currencyDecimalPlaces[strings.ToUpper(currencyCode)]; ok {
+		return places
+	}
+	return 2
+}
+
+//Float64 returns the nearest float64 to d
+func (d Decimal) Float64() float64 {
+	return float64(d.units) / decimalScale
+}
+
+//String returns d with as many decimal places as it needs e.g. "395.5"
+func (d Decimal) String() string {
+	s := d.StringFixed(DecimalPlaces)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+//StringFixed returns d rounded to places decimal places and formatted with exactly that many e.g. "395.50"
+func (d Decimal) StringFixed(places int) string {
+	if places > DecimalPlaces {
+		places = DecimalPlaces
+	}
+	if places < 0 {
+		places = 0
+	}
+	//The rounding is done with big.Int so that the extremes of the range can be formatted without overflowing
+	units := d.roundedUnits(pow10(DecimalPlaces - places))
+	sign := ""
+	if units.Sign() < 0 {
+		sign = "-"
+		units.Neg(units)
+	}
+	digits := units.String()
+	digits = strings.Repeat("0", max(0, places+1-len(digits))) + digits
+	whole, fraction := digits[:len(digits)-places], digits[len(digits)-places:]
+	if places == 0 {
+		return sign + whole
+	}
+	return sign + whole + "." + fraction
+}
+
+//Sum returns the total of values
+func Sum(values ...Decimal) Decimal {
+	var total Decimal
+	for _, value := range values {
+		total = total.Add(value)
+	}
+	return total
+}
+
+//MarshalText writes d in the form returned by String
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+//UnmarshalText reads a decimal number. An empty string is zero
+func (d *Decimal) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = Decimal{}
+		return nil
+	}
+	parsed, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+//MarshalJSON writes d as a JSON number
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+//UnmarshalJSON reads a JSON number, or a number in a string, without going through float64. null is zero
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	if strings.HasPrefix(string(data), `"`) {
+		var text string
+		err := json.Unmarshal(data, &text)
+		if err != nil {
+			return err
+		}
+		return d.UnmarshalText([]byte(text))
+	}
+	return d.UnmarshalText(data)
+}
+
+//MarshalXML writes d in the form returned by String. The element is left out when d is zero
+func (d Decimal) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	if d.IsZero() {
+		return nil
+	}
+	return encoder.EncodeElement(d.String(), start)
+}
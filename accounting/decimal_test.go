@@ -0,0 +1,155 @@
+package accounting
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Decimal_Sum(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var total Decimal
+	var floatTotal float64
+	for n := 0; n < 1000; n++ {
+		total = total.Add(MustParseDecimal("0.10"))
+		floatTotal += 0.10
+	}
+	a.Equal("100", total.String())
+	a.NotEqual(100.0, floatTotal, "float64 drifts which is why Decimal exists")
+
+	a.Equal("395.5", NewDecimal(39550, 2).String())
+	a.Equal("-0.0001", MustParseDecimal("-0.0001").String())
+	a.Equal("0.333333", mustDiv(NewDecimal(1, 0), NewDecimal(3, 0)).String())
+	a.Equal("12.3457", MustParseDecimal("3.0000").Mul(MustParseDecimal("4.11523")).Round(4).String())
+	a.Equal(1, Sum(NewDecimal(1, 0), NewDecimal(2, 0)).Cmp(NewDecimal(29999, 4)))
+
+	_, err := NewDecimal(1, 0).Div(Decimal{})
+	a.Error(err)
+	_, err = ParseDecimal("1/3")
+	a.Error(err)
+	_, err = ParseDecimal("99999999999999999999")
+	a.Error(err)
+}
+
+func Test_ParseDecimal_Syntax(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	for s, expected := range map[string]string{
+		"1234":                             "1234",
+		" -1234.5678 ":                     "-1234.5678",
+		"+0.5":                             "0.5",
+		"007.10":                           "7.1",
+		"0.0000004999999999":               "0",
+		"-0.0000005":                       "-0.000001",
+		"1." + strings.Repeat("9", 100000): "2",
+	} {
+		d, err := ParseDecimal(s)
+		if a.NoError(err, s) {
+			a.Equal(expected, d.String(), s)
+		}
+	}
+
+	for _, s := range []string{"", "-", ".5", "1.", "1e3", "1E-4", "1e999999999", "0x10", "1/3", "1_000", "Inf", "NaN", "--1", "1.2.3", "١٢"} {
+		_, err := ParseDecimal(s)
+		a.Error(err, s)
+	}
+
+	_, err := ParseDecimal("1" + strings.Repeat("0", 100000))
+	a.True(errors.Is(err, ErrDecimalOverflow))
+}
+
+func mustDiv(d Decimal, other Decimal) Decimal {
+	quotient, err := d.Div(other)
+	if err != nil {
+		panic(err)
+	}
+	return quotient
+}
+
+func Test_Decimal_Overflow(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	largest := Decimal{units: math.MaxInt64}
+	smallest := Decimal{units: math.MinInt64}
+
+	parsed, err := ParseDecimal("-9223372036854.775808")
+	a.NoError(err, "the most negative Decimal is a valid value")
+	a.True(parsed.Equal(smallest))
+	a.Equal("-9223372036854.775808", smallest.String())
+	a.Equal("-9223372036854.78", smallest.StringFixed(2))
+	a.Equal(-1, smallest.Cmp(largest))
+
+	_, err = ParseDecimal("9223372036854.775808")
+	a.True(errors.Is(err, ErrDecimalOverflow))
+
+	_, err = largest.CheckedAdd(NewDecimal(1, 6))
+	a.True(errors.Is(err, ErrDecimalOverflow))
+	_, err = smallest.CheckedSub(NewDecimal(1, 6))
+	a.True(errors.Is(err, ErrDecimalOverflow))
+	_, err = largest.CheckedMul(NewDecimal(2, 0))
+	a.True(errors.Is(err, ErrDecimalOverflow))
+	_, err = largest.CheckedMul(largest)
+	a.True(errors.Is(err, ErrDecimalOverflow))
+	_, err = largest.Div(NewDecimal(1, 1))
+	a.True(errors.Is(err, ErrDecimalOverflow))
+
+	sum, err := largest.CheckedAdd(NewDecimal(-1, 0))
+	a.NoError(err)
+	a.Equal("9223372036853.775807", sum.String())
+
+	a.Panics(func() { largest.Add(NewDecimal(1, 6)) })
+	a.Panics(func() { smallest.Neg() })
+	a.Panics(func() { largest.Round(0) })
+	a.Panics(func() { NewDecimal(math.MaxInt64, 0) })
+	a.Panics(func() { NewDecimalFromFloat(1e13) })
+	a.Panics(func() { NewDecimalFromFloat(math.Inf(-1)) })
+	a.Panics(func() { NewDecimalFromFloat(math.NaN()) })
+}
+
+func Test_Decimal_Rounding(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	a.Equal("2.35", MustParseDecimal("2.345").Round(2).String())
+	a.Equal("-2.35", MustParseDecimal("-2.345").Round(2).String())
+	a.Equal("1235", MustParseDecimal("1234.5").RoundToCurrency("JPY").String())
+	a.Equal("1.235", MustParseDecimal("1.2345").RoundToCurrency("kwd").String())
+	a.Equal("10.00", MustParseDecimal("9.999").StringFixed(2))
+	a.Equal("-0.50", MustParseDecimal("-0.5").StringFixed(2))
+	a.Equal("0.1", NewDecimalFromFloat(0.1).String())
+}
+
+func Test_Decimal_Encoding(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var lineItem LineItem
+	err := json.Unmarshal([]byte(`{"Quantity": 3.0000, "UnitAmount": 1234567.1235, "TaxAmount": "0.15", "LineAmount": null}`), &lineItem)
+	a.NoError(err)
+	a.Equal("3", lineItem.Quantity.String())
+	a.Equal("1234567.1235", lineItem.UnitAmount.String())
+	a.Equal("0.15", lineItem.TaxAmount.String())
+	a.True(lineItem.LineAmount.IsZero())
+
+	body, err := xml.Marshal(lineItem)
+	a.NoError(err)
+	a.Contains(string(body), "<UnitAmount>1234567.1235</UnitAmount>")
+	a.NotContains(string(body), "LineAmount")
+
+	var decoded LineItem
+	a.NoError(xml.Unmarshal(body, &decoded))
+	a.True(decoded.UnitAmount.Equal(lineItem.UnitAmount))
+
+	body, err = json.Marshal(lineItem)
+	a.NoError(err)
+	a.Contains(string(body), `"UnitAmount":1234567.1235`)
+}
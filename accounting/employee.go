@@ -1,17 +1,201 @@
 package accounting
 
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"time"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/markbates/goth"
+)
+
 //Employee is for the deprecated Pay run feature.
+//Employees can also be used as the payees of expense claims and receipts
 type Employee struct {
 
 	// The Xero identifier for an employee e.g. 297c2dc5-cc47-4afd-8ec8-74990b8761e9
-	EmployeeID string `json:"EmployeeID,omitempty"`
+	EmployeeID string `json:"EmployeeID,omitempty" xml:"EmployeeID,omitempty"`
 
 	// Current status of an employee – see contact status types
-	Status string `json:"Status,omitempty"`
+	Status ContactStatus `json:"Status,omitempty" xml:"Status,omitempty"`
 
 	// First name of an employee (max length = 255)
-	FirstName string `json:"FirstName,omitempty"`
+	FirstName string `json:"FirstName,omitempty" xml:"FirstName,omitempty"`
 
 	// Last name of an employee (max length = 255)
-	LastName string `json:"LastName,omitempty"`
+	LastName string `json:"LastName,omitempty" xml:"LastName,omitempty"`
+
+	// Link to an external resource, for example, an employee record in an external system. You can specify the URL element
+	ExternalLink *ExternalLink `json:"ExternalLink,omitempty" xml:"ExternalLink,omitempty"`
+
+	// Last modified date UTC format
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"-"`
+}
+
+//Employees is a collection of Employees
+type Employees struct {
+	Employees []Employee `json:"Employees" xml:"Employee"`
+}
+
+func unmarshalEmployee(employeeResponseBytes []byte) (*Employees, error) {
+	var employeeResponse *Employees
+	err := json.Unmarshal(employeeResponseBytes, &employeeResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return employeeResponse, err
+}
+
+//Validate checks the employees for problems Xero would reject them for, without sending them.
+//Fields that are only needed to create an employee are checked when it has no EmployeeID.
+//Create and Update call it before making a request
+func (e *Employees) Validate() error {
+	v := &validator{}
+	for n, employee := range e.Employees {
+		v.check(field("Employees", n, "Status"), employee.Status.Validate())
+		v.maxLength(field("Employees", n, "FirstName"), employee.FirstName, 255)
+		v.maxLength(field("Employees", n, "LastName"), employee.LastName, 255)
+
+		if employee.EmployeeID == "" {
+			v.required(field("Employees", n, "FirstName"), employee.FirstName != "")
+			v.required(field("Employees", n, "LastName"), employee.LastName != "")
+		}
+	}
+	return v.err()
+}
+
+//Create will create employees given an Employees struct
+func (e *Employees) Create(provider *xerogolang.Provider, session goth.Session) (*Employees, error) {
+	return e.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (e *Employees) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Employees, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	err := e.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := xml.MarshalIndent(e, "  ", "	")
+	if err != nil {
+		return nil, err
+	}
+
+	employeeResponseBytes, err := provider.CreateContext(ctx, session, "Employees", additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalEmployee(employeeResponseBytes)
+}
+
+//Update will update an employee given an Employees struct
+//This will only handle single employee - you cannot update multiple employees in a single call
+func (e *Employees) Update(provider *xerogolang.Provider, session goth.Session) (*Employees, error) {
+	return e.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (e *Employees) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Employees, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	err := e.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := xml.MarshalIndent(e, "  ", "	")
+	if err != nil {
+		return nil, err
+	}
+
+	employeeResponseBytes, err := provider.UpdateContext(ctx, session, "Employees/"+e.Employees[0].EmployeeID, additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalEmployee(employeeResponseBytes)
+}
+
+//FindEmployeesModifiedSince will get all employees modified after a specified date.
+//additional querystringParameters such as where and order can be added as a map
+func FindEmployeesModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Employees, error) {
+	return FindEmployeesModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindEmployeesModifiedSinceContext is like FindEmployeesModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindEmployeesModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Employees, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	if !modifiedSince.Equal(dayZero) {
+		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
+	}
+
+	employeeResponseBytes, err := provider.FindContext(ctx, session, "Employees", additionalHeaders, querystringParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalEmployee(employeeResponseBytes)
+}
+
+//FindEmployees will get all employees.
+//additional querystringParameters such as where and order can be added as a map
+func FindEmployees(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Employees, error) {
+	return FindEmployeesContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindEmployeesContext is like FindEmployees but uses ctx for the request so that it can be cancelled or given a deadline
+func FindEmployeesContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Employees, error) {
+	return FindEmployeesModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//FindEmployee will get a single employee - employeeID must be a GUID for an employee
+func FindEmployee(provider *xerogolang.Provider, session goth.Session, employeeID string) (*Employees, error) {
+	return FindEmployeeContext(context.Background(), provider, session, employeeID)
+}
+
+//FindEmployeeContext is like FindEmployee but uses ctx for the request so that it can be cancelled or given a deadline
+func FindEmployeeContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, employeeID string) (*Employees, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	employeeResponseBytes, err := provider.FindContext(ctx, session, "Employees/"+employeeID, additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalEmployee(employeeResponseBytes)
+}
+
+//GenerateExampleEmployee Creates an Example employee
+func GenerateExampleEmployee() *Employees {
+	employee := Employee{
+		FirstName: "Cosmo",
+		LastName:  "Kramer",
+		ExternalLink: &ExternalLink{
+			URL: "http://twitter.com/xeroapi",
+		},
+	}
+
+	employeeCollection := &Employees{
+		Employees: []Employee{},
+	}
+
+	employeeCollection.Employees = append(employeeCollection.Employees, employee)
+
+	return employeeCollection
 }
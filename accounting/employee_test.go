@@ -0,0 +1,66 @@
+package accounting
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Employees_Validate(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	a.NoError(GenerateExampleEmployee().Validate())
+
+	employees := &Employees{Employees: []Employee{{FirstName: "Cosmo", Status: "FIRED"}}}
+	a.Equal(ValidationErrors{
+		{Field: "Employees[0].Status", Message: `"FIRED" is not a valid contact status`},
+		{Field: "Employees[0].LastName", Message: "is required"},
+	}, employees.Validate())
+
+	employees = &Employees{Employees: []Employee{{EmployeeID: "employee-id", Status: ContactStatusArchived}}}
+	a.NoError(employees.Validate())
+}
+
+func Test_Employees(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("If-Modified-Since")+" "+string(body))
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"Employees": [{"EmployeeID": "employee-id", "Status": "ACTIVE", "FirstName": "Cosmo", "LastName": "Kramer", "ExternalLink": {"Url": "http://twitter.com/xeroapi"}, "UpdatedDateUTC": "/Date(1530403200000+0000)/"}]}`)
+	}))
+	defer ts.Close()
+	provider, session := testProvider(t, ts)
+
+	employees, err := GenerateExampleEmployee().Create(provider, session)
+	a.NoError(err)
+	employee := employees.Employees[0]
+	a.Equal("employee-id", employee.EmployeeID)
+	a.Equal(ContactStatusActive, employee.Status)
+	a.Equal("http://twitter.com/xeroapi", employee.ExternalLink.URL)
+
+	employees.Employees[0].LastName = "Van Nostrand"
+	_, err = employees.Update(provider, session)
+	a.NoError(err)
+
+	_, err = FindEmployeesModifiedSince(provider, session, time.Date(2018, time.July, 1, 0, 0, 0, 0, time.UTC), nil)
+	a.NoError(err)
+	_, err = FindEmployee(provider, session, "employee-id")
+	a.NoError(err)
+
+	a.Len(requests, 4)
+	a.Contains(requests[0], "PUT /api.xro/2.0/Employees ")
+	a.Contains(requests[0], "<Url>http://twitter.com/xeroapi</Url>")
+	a.Contains(requests[1], "POST /api.xro/2.0/Employees/employee-id ")
+	a.Contains(requests[1], "<LastName>Van Nostrand</LastName>")
+	a.Equal("GET /api.xro/2.0/Employees 2018-07-01T00:00:00Z ", requests[2])
+	a.Equal("GET /api.xro/2.0/Employees/employee-id  ", requests[3])
+}
@@ -0,0 +1,743 @@
+package accounting
+
+import (
+	"fmt"
+	"strings"
+)
+
+//parseEnum returns the value matching s, ignoring case
+func parseEnum[T ~string](s string, values []T, name string) (T, error) {
+	for _, value := range values {
+		if strings.EqualFold(string(value), s) {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("%q is not a valid %s", s, name)
+}
+
+//validateEnum allows value to be empty, letting Xero use its default, or one of values
+func validateEnum[T ~string](value T, values []T, name string) error {
+	if value == "" {
+		return nil
+	}
+	for _, valid := range values {
+		if value == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is not a valid %s", string(value), name)
+}
+
+//InvoiceType is the type of an invoice
+type InvoiceType string
+
+const (
+	//InvoiceTypeAccRec is a sales invoice (accounts receivable)
+	InvoiceTypeAccRec InvoiceType = "ACCREC"
+	//InvoiceTypeAccPay is a bill (accounts payable)
+	InvoiceTypeAccPay InvoiceType = "ACCPAY"
+)
+
+var invoiceTypes = []InvoiceType{InvoiceTypeAccRec, InvoiceTypeAccPay}
+
+//String returns the value Xero uses for the invoice type
+func (i InvoiceType) String() string {
+	return string(i)
+}
+
+//ParseInvoiceType returns the invoice type matching s, ignoring case
+func ParseInvoiceType(s string) (InvoiceType, error) {
+	return parseEnum(s, invoiceTypes, "invoice type")
+}
+
+//Validate returns an error if the invoice type is set to something Xero doesn't accept
+func (i InvoiceType) Validate() error {
+	return validateEnum(i, invoiceTypes, "invoice type")
+}
+
+//InvoiceStatus is the status of an invoice
+type InvoiceStatus string
+
+const (
+	//InvoiceStatusDraft is a draft that can still be edited
+	InvoiceStatusDraft InvoiceStatus = "DRAFT"
+	//InvoiceStatusSubmitted is waiting to be approved
+	InvoiceStatusSubmitted InvoiceStatus = "SUBMITTED"
+	//InvoiceStatusAuthorised is approved and awaiting payment
+	InvoiceStatusAuthorised InvoiceStatus = "AUTHORISED"
+	//InvoiceStatusPaid is fully paid
+	InvoiceStatusPaid InvoiceStatus = "PAID"
+	//InvoiceStatusDeleted is a deleted draft or submitted invoice
+	InvoiceStatusDeleted InvoiceStatus = "DELETED"
+	//InvoiceStatusVoided is a voided authorised invoice
+	InvoiceStatusVoided InvoiceStatus = "VOIDED"
+)
+
+var invoiceStatuses = []InvoiceStatus{InvoiceStatusDraft, InvoiceStatusSubmitted, InvoiceStatusAuthorised, InvoiceStatusPaid, InvoiceStatusDeleted, InvoiceStatusVoided}
+
+//String returns the value Xero uses for the invoice status
+func (i InvoiceStatus) String() string {
+	return string(i)
+}
+
+//ParseInvoiceStatus returns the invoice status matching s, ignoring case
+func ParseInvoiceStatus(s string) (InvoiceStatus, error) {
+	return parseEnum(s, invoiceStatuses, "invoice status")
+}
+
+//Validate returns an error if the invoice status is set to something Xero doesn't accept
+func (i InvoiceStatus) Validate() error {
+	return validateEnum(i, invoiceStatuses, "invoice status")
+}
+
+//CreditNoteType is the type of a credit note
+type CreditNoteType string
+
+const (
+	//CreditNoteTypeAccPayCredit is a credit note from a supplier
+	CreditNoteTypeAccPayCredit CreditNoteType = "ACCPAYCREDIT"
+	//CreditNoteTypeAccRecCredit is a credit note to a customer
+	CreditNoteTypeAccRecCredit CreditNoteType = "ACCRECCREDIT"
+)
+
+var creditNoteTypes = []CreditNoteType{CreditNoteTypeAccPayCredit, CreditNoteTypeAccRecCredit}
+
+//String returns the value Xero uses for the credit note type
+func (c CreditNoteType) String() string {
+	return string(c)
+}
+
+//ParseCreditNoteType returns the credit note type matching s, ignoring case
+func ParseCreditNoteType(s string) (CreditNoteType, error) {
+	return parseEnum(s, creditNoteTypes, "credit note type")
+}
+
+//Validate returns an error if the credit note type is set to something Xero doesn't accept
+func (c CreditNoteType) Validate() error {
+	return validateEnum(c, creditNoteTypes, "credit note type")
+}
+
+//CreditNoteStatus is the status of a credit note
+type CreditNoteStatus string
+
+const (
+	//CreditNoteStatusDraft is a draft that can still be edited
+	CreditNoteStatusDraft CreditNoteStatus = "DRAFT"
+	//CreditNoteStatusSubmitted is waiting to be approved
+	CreditNoteStatusSubmitted CreditNoteStatus = "SUBMITTED"
+	//CreditNoteStatusAuthorised is approved and available to allocate
+	CreditNoteStatusAuthorised CreditNoteStatus = "AUTHORISED"
+	//CreditNoteStatusPaid is fully allocated or refunded
+	CreditNoteStatusPaid CreditNoteStatus = "PAID"
+	//CreditNoteStatusDeleted is a deleted draft or submitted credit note
+	CreditNoteStatusDeleted CreditNoteStatus = "DELETED"
+	//CreditNoteStatusVoided is a voided authorised credit note
+	CreditNoteStatusVoided CreditNoteStatus = "VOIDED"
+)
+
+var creditNoteStatuses = []CreditNoteStatus{CreditNoteStatusDraft, CreditNoteStatusSubmitted, CreditNoteStatusAuthorised, CreditNoteStatusPaid, CreditNoteStatusDeleted, CreditNoteStatusVoided}
+
+//String returns the value Xero uses for the credit note status
+func (c CreditNoteStatus) String() string {
+	return string(c)
+}
+
+//ParseCreditNoteStatus returns the credit note status matching s, ignoring case
+func ParseCreditNoteStatus(s string) (CreditNoteStatus, error) {
+	return parseEnum(s, creditNoteStatuses, "credit note status")
+}
+
+//Validate returns an error if the credit note status is set to something Xero doesn't accept
+func (c CreditNoteStatus) Validate() error {
+	return validateEnum(c, creditNoteStatuses, "credit note status")
+}
+
+//LineAmountType is how the line amounts of a document treat tax
+type LineAmountType string
+
+const (
+	//LineAmountExclusive is line amounts exclude tax
+	LineAmountExclusive LineAmountType = "Exclusive"
+	//LineAmountInclusive is line amounts include tax
+	LineAmountInclusive LineAmountType = "Inclusive"
+	//LineAmountNoTax is line amounts have no tax
+	LineAmountNoTax LineAmountType = "NoTax"
+)
+
+var lineAmountTypes = []LineAmountType{LineAmountExclusive, LineAmountInclusive, LineAmountNoTax}
+
+//String returns the value Xero uses for the line amount type
+func (l LineAmountType) String() string {
+	return string(l)
+}
+
+//ParseLineAmountType returns the line amount type matching s, ignoring case
+func ParseLineAmountType(s string) (LineAmountType, error) {
+	return parseEnum(s, lineAmountTypes, "line amount type")
+}
+
+//Validate returns an error if the line amount type is set to something Xero doesn't accept
+func (l LineAmountType) Validate() error {
+	return validateEnum(l, lineAmountTypes, "line amount type")
+}
+
+//BankTransactionType is the type of a bank transaction
+type BankTransactionType string
+
+const (
+	//BankTransactionTypeReceive is money received
+	BankTransactionTypeReceive BankTransactionType = "RECEIVE"
+	//BankTransactionTypeSpend is money spent
+	BankTransactionTypeSpend BankTransactionType = "SPEND"
+	//BankTransactionTypeReceiveOverpayment is an overpayment received
+	BankTransactionTypeReceiveOverpayment BankTransactionType = "RECEIVE-OVERPAYMENT"
+	//BankTransactionTypeSpendOverpayment is an overpayment made
+	BankTransactionTypeSpendOverpayment BankTransactionType = "SPEND-OVERPAYMENT"
+	//BankTransactionTypeReceivePrepayment is a prepayment received
+	BankTransactionTypeReceivePrepayment BankTransactionType = "RECEIVE-PREPAYMENT"
+	//BankTransactionTypeSpendPrepayment is a prepayment made
+	BankTransactionTypeSpendPrepayment BankTransactionType = "SPEND-PREPAYMENT"
+	//BankTransactionTypeReceiveTransfer is the receiving side of a bank transfer
+	BankTransactionTypeReceiveTransfer BankTransactionType = "RECEIVE-TRANSFER"
+	//BankTransactionTypeSpendTransfer is the sending side of a bank transfer
+	BankTransactionTypeSpendTransfer BankTransactionType = "SPEND-TRANSFER"
+)
+
+var bankTransactionTypes = []BankTransactionType{BankTransactionTypeReceive, BankTransactionTypeSpend, BankTransactionTypeReceiveOverpayment, BankTransactionTypeSpendOverpayment, BankTransactionTypeReceivePrepayment, BankTransactionTypeSpendPrepayment, BankTransactionTypeReceiveTransfer, BankTransactionTypeSpendTransfer}
+
+//String returns the value Xero uses for the bank transaction type
+func (b BankTransactionType) String() string {
+	return string(b)
+}
+
+//ParseBankTransactionType returns the bank transaction type matching s, ignoring case
+func ParseBankTransactionType(s string) (BankTransactionType, error) {
+	return parseEnum(s, bankTransactionTypes, "bank transaction type")
+}
+
+//Validate returns an error if the bank transaction type is set to something Xero doesn't accept
+func (b BankTransactionType) Validate() error {
+	return validateEnum(b, bankTransactionTypes, "bank transaction type")
+}
+
+//BankTransactionStatus is the status of a bank transaction
+type BankTransactionStatus string
+
+const (
+	//BankTransactionStatusAuthorised is an active transaction
+	BankTransactionStatusAuthorised BankTransactionStatus = "AUTHORISED"
+	//BankTransactionStatusDeleted is a deleted transaction
+	BankTransactionStatusDeleted BankTransactionStatus = "DELETED"
+	//BankTransactionStatusVoided is a voided transaction
+	BankTransactionStatusVoided BankTransactionStatus = "VOIDED"
+)
+
+var bankTransactionStatuses = []BankTransactionStatus{BankTransactionStatusAuthorised, BankTransactionStatusDeleted, BankTransactionStatusVoided}
+
+//String returns the value Xero uses for the bank transaction status
+func (b BankTransactionStatus) String() string {
+	return string(b)
+}
+
+//ParseBankTransactionStatus returns the bank transaction status matching s, ignoring case
+func ParseBankTransactionStatus(s string) (BankTransactionStatus, error) {
+	return parseEnum(s, bankTransactionStatuses, "bank transaction status")
+}
+
+//Validate returns an error if the bank transaction status is set to something Xero doesn't accept
+func (b BankTransactionStatus) Validate() error {
+	return validateEnum(b, bankTransactionStatuses, "bank transaction status")
+}
+
+//AccountType is the type of an account in the chart of accounts
+type AccountType string
+
+const (
+	//AccountTypeBank is a bank account
+	AccountTypeBank AccountType = "BANK"
+	//AccountTypeCurrent is a current asset
+	AccountTypeCurrent AccountType = "CURRENT"
+	//AccountTypeCurrentLiability is a current liability
+	AccountTypeCurrentLiability AccountType = "CURRLIAB"
+	//AccountTypeDepreciation is depreciation
+	AccountTypeDepreciation AccountType = "DEPRECIATN"
+	//AccountTypeDirectCosts is direct costs
+	AccountTypeDirectCosts AccountType = "DIRECTCOSTS"
+	//AccountTypeEquity is equity
+	AccountTypeEquity AccountType = "EQUITY"
+	//AccountTypeExpense is an expense
+	AccountTypeExpense AccountType = "EXPENSE"
+	//AccountTypeFixed is a fixed asset
+	AccountTypeFixed AccountType = "FIXED"
+	//AccountTypeInventory is an inventory asset
+	AccountTypeInventory AccountType = "INVENTORY"
+	//AccountTypeLiability is a liability
+	AccountTypeLiability AccountType = "LIABILITY"
+	//AccountTypeNonCurrent is a non-current asset
+	AccountTypeNonCurrent AccountType = "NONCURRENT"
+	//AccountTypeOtherIncome is other income
+	AccountTypeOtherIncome AccountType = "OTHERINCOME"
+	//AccountTypeOverheads is overheads
+	AccountTypeOverheads AccountType = "OVERHEADS"
+	//AccountTypePrepayment is a prepayment
+	AccountTypePrepayment AccountType = "PREPAYMENT"
+	//AccountTypeRevenue is revenue
+	AccountTypeRevenue AccountType = "REVENUE"
+	//AccountTypeSales is sales
+	AccountTypeSales AccountType = "SALES"
+	//AccountTypeTermLiability is a non-current liability
+	AccountTypeTermLiability AccountType = "TERMLIAB"
+	//AccountTypePAYGLiability is a PAYG liability
+	AccountTypePAYGLiability AccountType = "PAYGLIABILITY"
+	//AccountTypeSuperannuationExpense is a superannuation expense
+	AccountTypeSuperannuationExpense AccountType = "SUPERANNUATIONEXPENSE"
+	//AccountTypeSuperannuationLiability is a superannuation liability
+	AccountTypeSuperannuationLiability AccountType = "SUPERANNUATIONLIABILITY"
+	//AccountTypeWagesExpense is a wages expense
+	AccountTypeWagesExpense AccountType = "WAGESEXPENSE"
+	//AccountTypeWagesPayableLiability is wages payable
+	AccountTypeWagesPayableLiability AccountType = "WAGESPAYABLELIABILITY"
+)
+
+var accountTypes = []AccountType{AccountTypeBank, AccountTypeCurrent, AccountTypeCurrentLiability, AccountTypeDepreciation, AccountTypeDirectCosts, AccountTypeEquity, AccountTypeExpense, AccountTypeFixed, AccountTypeInventory, AccountTypeLiability, AccountTypeNonCurrent, AccountTypeOtherIncome, AccountTypeOverheads, AccountTypePrepayment, AccountTypeRevenue, AccountTypeSales, AccountTypeTermLiability, AccountTypePAYGLiability, AccountTypeSuperannuationExpense, AccountTypeSuperannuationLiability, AccountTypeWagesExpense, AccountTypeWagesPayableLiability}
+
+//String returns the value Xero uses for the account type
+func (a AccountType) String() string {
+	return string(a)
+}
+
+//ParseAccountType returns the account type matching s, ignoring case
+func ParseAccountType(s string) (AccountType, error) {
+	return parseEnum(s, accountTypes, "account type")
+}
+
+//Validate returns an error if the account type is set to something Xero doesn't accept
+func (a AccountType) Validate() error {
+	return validateEnum(a, accountTypes, "account type")
+}
+
+//AccountStatus is the status of an account
+type AccountStatus string
+
+const (
+	//AccountStatusActive is an account in use
+	AccountStatusActive AccountStatus = "ACTIVE"
+	//AccountStatusArchived is an archived account
+	AccountStatusArchived AccountStatus = "ARCHIVED"
+	//AccountStatusDeleted is a deleted account
+	AccountStatusDeleted AccountStatus = "DELETED"
+)
+
+var accountStatuses = []AccountStatus{AccountStatusActive, AccountStatusArchived, AccountStatusDeleted}
+
+//String returns the value Xero uses for the account status
+func (a AccountStatus) String() string {
+	return string(a)
+}
+
+//ParseAccountStatus returns the account status matching s, ignoring case
+func ParseAccountStatus(s string) (AccountStatus, error) {
+	return parseEnum(s, accountStatuses, "account status")
+}
+
+//Validate returns an error if the account status is set to something Xero doesn't accept
+func (a AccountStatus) Validate() error {
+	return validateEnum(a, accountStatuses, "account status")
+}
+
+//AccountClass is the class an account type belongs to
+type AccountClass string
+
+const (
+	//AccountClassAsset is an asset
+	AccountClassAsset AccountClass = "ASSET"
+	//AccountClassEquity is equity
+	AccountClassEquity AccountClass = "EQUITY"
+	//AccountClassExpense is an expense
+	AccountClassExpense AccountClass = "EXPENSE"
+	//AccountClassLiability is a liability
+	AccountClassLiability AccountClass = "LIABILITY"
+	//AccountClassRevenue is revenue
+	AccountClassRevenue AccountClass = "REVENUE"
+)
+
+var accountClasses = []AccountClass{AccountClassAsset, AccountClassEquity, AccountClassExpense, AccountClassLiability, AccountClassRevenue}
+
+//String returns the value Xero uses for the account class
+func (a AccountClass) String() string {
+	return string(a)
+}
+
+//ParseAccountClass returns the account class matching s, ignoring case
+func ParseAccountClass(s string) (AccountClass, error) {
+	return parseEnum(s, accountClasses, "account class")
+}
+
+//Validate returns an error if the account class is set to something Xero doesn't accept
+func (a AccountClass) Validate() error {
+	return validateEnum(a, accountClasses, "account class")
+}
+
+//ContactStatus is the status of a contact
+type ContactStatus string
+
+const (
+	//ContactStatusActive is a contact in use
+	ContactStatusActive ContactStatus = "ACTIVE"
+	//ContactStatusArchived is an archived contact
+	ContactStatusArchived ContactStatus = "ARCHIVED"
+	//ContactStatusGDPRRequest is a contact whose details are being removed at their request
+	ContactStatusGDPRRequest ContactStatus = "GDPRREQUEST"
+)
+
+var contactStatuses = []ContactStatus{ContactStatusActive, ContactStatusArchived, ContactStatusGDPRRequest}
+
+//String returns the value Xero uses for the contact status
+func (c ContactStatus) String() string {
+	return string(c)
+}
+
+//ParseContactStatus returns the contact status matching s, ignoring case
+func ParseContactStatus(s string) (ContactStatus, error) {
+	return parseEnum(s, contactStatuses, "contact status")
+}
+
+//Validate returns an error if the contact status is set to something Xero doesn't accept
+func (c ContactStatus) Validate() error {
+	return validateEnum(c, contactStatuses, "contact status")
+}
+
+//PaymentStatus is the status of a payment
+type PaymentStatus string
+
+const (
+	//PaymentStatusAuthorised is an active payment
+	PaymentStatusAuthorised PaymentStatus = "AUTHORISED"
+	//PaymentStatusDeleted is a deleted payment
+	PaymentStatusDeleted PaymentStatus = "DELETED"
+)
+
+var paymentStatuses = []PaymentStatus{PaymentStatusAuthorised, PaymentStatusDeleted}
+
+//String returns the value Xero uses for the payment status
+func (p PaymentStatus) String() string {
+	return string(p)
+}
+
+//ParsePaymentStatus returns the payment status matching s, ignoring case
+func ParsePaymentStatus(s string) (PaymentStatus, error) {
+	return parseEnum(s, paymentStatuses, "payment status")
+}
+
+//Validate returns an error if the payment status is set to something Xero doesn't accept
+func (p PaymentStatus) Validate() error {
+	return validateEnum(p, paymentStatuses, "payment status")
+}
+
+//PaymentType is the kind of document a payment was made against
+type PaymentType string
+
+const (
+	//PaymentTypeAccRecPayment is a payment of a sales invoice
+	PaymentTypeAccRecPayment PaymentType = "ACCRECPAYMENT"
+	//PaymentTypeAccPayPayment is a payment of a bill
+	PaymentTypeAccPayPayment PaymentType = "ACCPAYPAYMENT"
+	//PaymentTypeARCreditPayment is a refund of a sales credit note
+	PaymentTypeARCreditPayment PaymentType = "ARCREDITPAYMENT"
+	//PaymentTypeAPCreditPayment is a refund of a purchases credit note
+	PaymentTypeAPCreditPayment PaymentType = "APCREDITPAYMENT"
+	//PaymentTypeAROverpaymentPayment is a refund of a received overpayment
+	PaymentTypeAROverpaymentPayment PaymentType = "AROVERPAYMENTPAYMENT"
+	//PaymentTypeARPrepaymentPayment is a refund of a received prepayment
+	PaymentTypeARPrepaymentPayment PaymentType = "ARPREPAYMENTPAYMENT"
+	//PaymentTypeAPPrepaymentPayment is a refund of a prepayment made
+	PaymentTypeAPPrepaymentPayment PaymentType = "APPREPAYMENTPAYMENT"
+	//PaymentTypeAPOverpaymentPayment is a refund of an overpayment made
+	PaymentTypeAPOverpaymentPayment PaymentType = "APOVERPAYMENTPAYMENT"
+)
+
+var paymentTypes = []PaymentType{PaymentTypeAccRecPayment, PaymentTypeAccPayPayment, PaymentTypeARCreditPayment, PaymentTypeAPCreditPayment, PaymentTypeAROverpaymentPayment, PaymentTypeARPrepaymentPayment, PaymentTypeAPPrepaymentPayment, PaymentTypeAPOverpaymentPayment}
+
+//String returns the value Xero uses for the payment type
+func (p PaymentType) String() string {
+	return string(p)
+}
+
+//ParsePaymentType returns the payment type matching s, ignoring case
+func ParsePaymentType(s string) (PaymentType, error) {
+	return parseEnum(s, paymentTypes, "payment type")
+}
+
+//Validate returns an error if the payment type is set to something Xero doesn't accept
+func (p PaymentType) Validate() error {
+	return validateEnum(p, paymentTypes, "payment type")
+}
+
+//PurchaseOrderStatus is the status of a purchase order
+type PurchaseOrderStatus string
+
+const (
+	//PurchaseOrderStatusDraft is a draft that can still be edited
+	PurchaseOrderStatusDraft PurchaseOrderStatus = "DRAFT"
+	//PurchaseOrderStatusSubmitted is waiting to be approved
+	PurchaseOrderStatusSubmitted PurchaseOrderStatus = "SUBMITTED"
+	//PurchaseOrderStatusAuthorised is approved and sent to the supplier
+	PurchaseOrderStatusAuthorised PurchaseOrderStatus = "AUTHORISED"
+	//PurchaseOrderStatusBilled is billed by the supplier
+	PurchaseOrderStatusBilled PurchaseOrderStatus = "BILLED"
+	//PurchaseOrderStatusDeleted is a deleted purchase order
+	PurchaseOrderStatusDeleted PurchaseOrderStatus = "DELETED"
+)
+
+var purchaseOrderStatuses = []PurchaseOrderStatus{PurchaseOrderStatusDraft, PurchaseOrderStatusSubmitted, PurchaseOrderStatusAuthorised, PurchaseOrderStatusBilled, PurchaseOrderStatusDeleted}
+
+//String returns the value Xero uses for the purchase order status
+func (p PurchaseOrderStatus) String() string {
+	return string(p)
+}
+
+//ParsePurchaseOrderStatus returns the purchase order status matching s, ignoring case
+func ParsePurchaseOrderStatus(s string) (PurchaseOrderStatus, error) {
+	return parseEnum(s, purchaseOrderStatuses, "purchase order status")
+}
+
+//Validate returns an error if the purchase order status is set to something Xero doesn't accept
+func (p PurchaseOrderStatus) Validate() error {
+	return validateEnum(p, purchaseOrderStatuses, "purchase order status")
+}
+
+//ManualJournalStatus is the status of a manual journal
+type ManualJournalStatus string
+
+const (
+	//ManualJournalStatusDraft is a draft that can still be edited
+	ManualJournalStatusDraft ManualJournalStatus = "DRAFT"
+	//ManualJournalStatusPosted is posted to the ledger
+	ManualJournalStatusPosted ManualJournalStatus = "POSTED"
+	//ManualJournalStatusDeleted is a deleted draft
+	ManualJournalStatusDeleted ManualJournalStatus = "DELETED"
+	//ManualJournalStatusVoided is a voided posted journal
+	ManualJournalStatusVoided ManualJournalStatus = "VOIDED"
+	//ManualJournalStatusArchived is an archived journal
+	ManualJournalStatusArchived ManualJournalStatus = "ARCHIVED"
+)
+
+var manualJournalStatuses = []ManualJournalStatus{ManualJournalStatusDraft, ManualJournalStatusPosted, ManualJournalStatusDeleted, ManualJournalStatusVoided, ManualJournalStatusArchived}
+
+//String returns the value Xero uses for the manual journal status
+func (m ManualJournalStatus) String() string {
+	return string(m)
+}
+
+//ParseManualJournalStatus returns the manual journal status matching s, ignoring case
+func ParseManualJournalStatus(s string) (ManualJournalStatus, error) {
+	return parseEnum(s, manualJournalStatuses, "manual journal status")
+}
+
+//Validate returns an error if the manual journal status is set to something Xero doesn't accept
+func (m ManualJournalStatus) Validate() error {
+	return validateEnum(m, manualJournalStatuses, "manual journal status")
+}
+
+//RepeatingInvoiceStatus is the status of a repeating invoice template
+type RepeatingInvoiceStatus string
+
+const (
+	//RepeatingInvoiceStatusDraft is invoices are created as drafts
+	RepeatingInvoiceStatusDraft RepeatingInvoiceStatus = "DRAFT"
+	//RepeatingInvoiceStatusAuthorised is invoices are created approved
+	RepeatingInvoiceStatusAuthorised RepeatingInvoiceStatus = "AUTHORISED"
+	//RepeatingInvoiceStatusDeleted is a deleted template
+	RepeatingInvoiceStatusDeleted RepeatingInvoiceStatus = "DELETED"
+)
+
+var repeatingInvoiceStatuses = []RepeatingInvoiceStatus{RepeatingInvoiceStatusDraft, RepeatingInvoiceStatusAuthorised, RepeatingInvoiceStatusDeleted}
+
+//String returns the value Xero uses for the repeating invoice status
+func (r RepeatingInvoiceStatus) String() string {
+	return string(r)
+}
+
+//ParseRepeatingInvoiceStatus returns the repeating invoice status matching s, ignoring case
+func ParseRepeatingInvoiceStatus(s string) (RepeatingInvoiceStatus, error) {
+	return parseEnum(s, repeatingInvoiceStatuses, "repeating invoice status")
+}
+
+//Validate returns an error if the repeating invoice status is set to something Xero doesn't accept
+func (r RepeatingInvoiceStatus) Validate() error {
+	return validateEnum(r, repeatingInvoiceStatuses, "repeating invoice status")
+}
+
+//QuoteStatus is the status of a quote
+type QuoteStatus string
+
+const (
+	//QuoteStatusDraft is a draft that can still be edited
+	QuoteStatusDraft QuoteStatus = "DRAFT"
+	//QuoteStatusSent is sent to the customer
+	QuoteStatusSent QuoteStatus = "SENT"
+	//QuoteStatusAccepted is accepted by the customer
+	QuoteStatusAccepted QuoteStatus = "ACCEPTED"
+	//QuoteStatusDeclined is declined by the customer
+	QuoteStatusDeclined QuoteStatus = "DECLINED"
+	//QuoteStatusInvoiced is an accepted quote that has been invoiced
+	QuoteStatusInvoiced QuoteStatus = "INVOICED"
+	//QuoteStatusDeleted is a deleted quote
+	QuoteStatusDeleted QuoteStatus = "DELETED"
+)
+
+var quoteStatuses = []QuoteStatus{QuoteStatusDraft, QuoteStatusSent, QuoteStatusAccepted, QuoteStatusDeclined, QuoteStatusInvoiced, QuoteStatusDeleted}
+
+//String returns the value Xero uses for the quote status
+func (q QuoteStatus) String() string {
+	return string(q)
+}
+
+//ParseQuoteStatus returns the quote status matching s, ignoring case
+func ParseQuoteStatus(s string) (QuoteStatus, error) {
+	return parseEnum(s, quoteStatuses, "quote status")
+}
+
+//Validate returns an error if the quote status is set to something Xero doesn't accept
+func (q QuoteStatus) Validate() error {
+	return validateEnum(q, quoteStatuses, "quote status")
+}
+
+//BatchPaymentType is the kind of invoices paid by a batch payment
+type BatchPaymentType string
+
+const (
+	//BatchPaymentTypePayBatch pays bills (ACCPAY invoices)
+	BatchPaymentTypePayBatch BatchPaymentType = "PAYBATCH"
+	//BatchPaymentTypeRecBatch receives payment for sales invoices (ACCREC invoices)
+	BatchPaymentTypeRecBatch BatchPaymentType = "RECBATCH"
+)
+
+var batchPaymentTypes = []BatchPaymentType{BatchPaymentTypePayBatch, BatchPaymentTypeRecBatch}
+
+//String returns the value Xero uses for the batch payment type
+func (b BatchPaymentType) String() string {
+	return string(b)
+}
+
+//ParseBatchPaymentType returns the batch payment type matching s, ignoring case
+func ParseBatchPaymentType(s string) (BatchPaymentType, error) {
+	return parseEnum(s, batchPaymentTypes, "batch payment type")
+}
+
+//Validate returns an error if the batch payment type is set to something Xero doesn't accept
+func (b BatchPaymentType) Validate() error {
+	return validateEnum(b, batchPaymentTypes, "batch payment type")
+}
+
+//BatchPaymentStatus is the status of a batch payment
+type BatchPaymentStatus string
+
+const (
+	//BatchPaymentStatusAuthorised is an active batch payment
+	BatchPaymentStatusAuthorised BatchPaymentStatus = "AUTHORISED"
+	//BatchPaymentStatusDeleted is a deleted batch payment
+	BatchPaymentStatusDeleted BatchPaymentStatus = "DELETED"
+)
+
+var batchPaymentStatuses = []BatchPaymentStatus{BatchPaymentStatusAuthorised, BatchPaymentStatusDeleted}
+
+//String returns the value Xero uses for the batch payment status
+func (b BatchPaymentStatus) String() string {
+	return string(b)
+}
+
+//ParseBatchPaymentStatus returns the batch payment status matching s, ignoring case
+func ParseBatchPaymentStatus(s string) (BatchPaymentStatus, error) {
+	return parseEnum(s, batchPaymentStatuses, "batch payment status")
+}
+
+//Validate returns an error if the batch payment status is set to something Xero doesn't accept
+func (b BatchPaymentStatus) Validate() error {
+	return validateEnum(b, batchPaymentStatuses, "batch payment status")
+}
+
+//ScheduleUnit is the unit of the period between the invoices of a repeating invoice
+type ScheduleUnit string
+
+const (
+	//ScheduleUnitWeekly repeats every Period weeks
+	ScheduleUnitWeekly ScheduleUnit = "WEEKLY"
+	//ScheduleUnitMonthly repeats every Period months
+	ScheduleUnitMonthly ScheduleUnit = "MONTHLY"
+)
+
+var scheduleUnits = []ScheduleUnit{ScheduleUnitWeekly, ScheduleUnitMonthly}
+
+//String returns the value Xero uses for the schedule unit
+func (s ScheduleUnit) String() string {
+	return string(s)
+}
+
+//ParseScheduleUnit returns the schedule unit matching s, ignoring case
+func ParseScheduleUnit(s string) (ScheduleUnit, error) {
+	return parseEnum(s, scheduleUnits, "schedule unit")
+}
+
+//Validate returns an error if the schedule unit is set to something Xero doesn't accept
+func (s ScheduleUnit) Validate() error {
+	return validateEnum(s, scheduleUnits, "schedule unit")
+}
+
+//DueDateType says how the DueDate of a repeating invoice's schedule is counted
+type DueDateType string
+
+const (
+	//DueDateTypeDaysAfterBillDate is due DueDate days after the invoice date
+	DueDateTypeDaysAfterBillDate DueDateType = "DAYSAFTERBILLDATE"
+	//DueDateTypeDaysAfterBillMonth is due DueDate days after the end of the invoice month
+	DueDateTypeDaysAfterBillMonth DueDateType = "DAYSAFTERBILLMONTH"
+	//DueDateTypeOfCurrentMonth is due on day DueDate of the invoice month
+	DueDateTypeOfCurrentMonth DueDateType = "OFCURRENTMONTH"
+	//DueDateTypeOfFollowingMonth is due on day DueDate of the month after the invoice
+	DueDateTypeOfFollowingMonth DueDateType = "OFFOLLOWINGMONTH"
+)
+
+var dueDateTypes = []DueDateType{DueDateTypeDaysAfterBillDate, DueDateTypeDaysAfterBillMonth, DueDateTypeOfCurrentMonth, DueDateTypeOfFollowingMonth}
+
+//String returns the value Xero uses for the due date type
+func (d DueDateType) String() string {
+	return string(d)
+}
+
+//ParseDueDateType returns the due date type matching s, ignoring case
+func ParseDueDateType(s string) (DueDateType, error) {
+	return parseEnum(s, dueDateTypes, "due date type")
+}
+
+//Validate returns an error if the due date type is set to something Xero doesn't accept
+func (d DueDateType) Validate() error {
+	return validateEnum(d, dueDateTypes, "due date type")
+}
+
+//BudgetType is the kind of a budget
+type BudgetType string
+
+const (
+	//BudgetTypeOverall is the organisation's overall budget
+	BudgetTypeOverall BudgetType = "OVERALL"
+	//BudgetTypeTracking is a budget for one option of a tracking category
+	BudgetTypeTracking BudgetType = "TRACKING"
+)
+
+var budgetTypes = []BudgetType{BudgetTypeOverall, BudgetTypeTracking}
+
+//String returns the value Xero uses for the budget type
+func (b BudgetType) String() string {
+	return string(b)
+}
+
+//ParseBudgetType returns the budget type matching s, ignoring case
+func ParseBudgetType(s string) (BudgetType, error) {
+	return parseEnum(s, budgetTypes, "budget type")
+}
+
+//Validate returns an error if the budget type is set to something Xero doesn't accept
+func (b BudgetType) Validate() error {
+	return validateEnum(b, budgetTypes, "budget type")
+}
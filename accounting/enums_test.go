@@ -0,0 +1,52 @@
+package accounting
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseEnums(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	invoiceType, err := ParseInvoiceType("accrec")
+	a.NoError(err)
+	a.Equal(InvoiceTypeAccRec, invoiceType)
+	a.Equal("ACCREC", invoiceType.String())
+
+	lineAmountType, err := ParseLineAmountType("NOTAX")
+	a.NoError(err)
+	a.Equal(LineAmountNoTax, lineAmountType)
+
+	_, err = ParseBankTransactionType("WITHDRAW")
+	a.EqualError(err, `"WITHDRAW" is not a valid bank transaction type`)
+
+	a.NoError(InvoiceStatus("").Validate(), "an empty value lets Xero use its default")
+	a.Error(InvoiceStatus("APPROVED").Validate())
+	a.NoError(AccountTypeSales.Validate())
+}
+
+func Test_Invoices_Validate(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var invoices Invoices
+	a.NoError(json.Unmarshal([]byte(`{"Invoices": [{"InvoiceID": "1", "Type": "ACCREC", "Status": "AUTHORISED"}, {"InvoiceID": "2", "Type": "ACCREC", "Status": "APPROVED", "LineAmountTypes": "Excl"}]}`), &invoices))
+
+	err := invoices.Validate()
+	a.Error(err)
+	validationErrors, ok := err.(ValidationErrors)
+	a.True(ok)
+	a.Equal(ValidationErrors{
+		{Field: "Invoices[1].Status", Message: `"APPROVED" is not a valid invoice status`},
+		{Field: "Invoices[1].LineAmountTypes", Message: `"Excl" is not a valid line amount type`},
+	}, validationErrors)
+
+	//Nothing is sent to Xero, so no provider is needed to see the error
+	_, err = invoices.Create(nil, nil)
+	a.Equal(validationErrors, err)
+
+	a.NoError(GenerateExampleInvoice().Validate())
+}
@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -28,22 +28,22 @@ type ExpenseClaim struct {
 	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
 
 	// Last modified date UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"-"`
 
 	// The total of an expense claim being paid
-	Total float64 `json:"Total,omitempty" xml:"Total,omitempty"`
+	Total Decimal `json:"Total,omitempty" xml:"Total,omitempty"`
 
 	// The amount due to be paid for an expense claim
-	AmountDue float64 `json:"AmountDue,omitempty" xml:"AmountDue,omitempty"`
+	AmountDue Decimal `json:"AmountDue,omitempty" xml:"AmountDue,omitempty"`
 
 	// The amount still to pay for an expense claim
-	AmountPaid float64 `json:"AmountPaid,omitempty" xml:"AmountPaid,omitempty"`
+	AmountPaid Decimal `json:"AmountPaid,omitempty" xml:"AmountPaid,omitempty"`
 
 	// The date when the expense claim is due to be paid YYYY-MM-DD
-	PaymentDueDate string `json:"PaymentDueDate,omitempty" xml:"PaymentDueDate,omitempty"`
+	PaymentDueDate Date `json:"PaymentDueDate,omitempty" xml:"PaymentDueDate,omitempty"`
 
 	// The date the expense claim will be reported in Xero YYYY-MM-DD
-	ReportingDate string `json:"ReportingDate,omitempty" xml:"ReportingDate,omitempty"`
+	ReportingDate Date `json:"ReportingDate,omitempty" xml:"ReportingDate,omitempty"`
 
 	// The Xero identifier for the Receipt e.g. e59a2c7f-1306-4078-a0f3-73537afcbba9
 	ReceiptID string `json:"ReceiptID" xml:"ReceiptID"`
@@ -54,20 +54,6 @@ type ExpenseClaims struct {
 	ExpenseClaims []ExpenseClaim `json:"ExpenseClaims" xml:"ExpenseClaim"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (e *ExpenseClaims) convertDates() error {
-	var err error
-	for n := len(e.ExpenseClaims) - 1; n >= 0; n-- {
-		e.ExpenseClaims[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(e.ExpenseClaims[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalExpenseClaim(expenseClaimResponseBytes []byte) (*ExpenseClaims, error) {
 	var expenseClaimResponse *ExpenseClaims
 	err := json.Unmarshal(expenseClaimResponseBytes, &expenseClaimResponse)
@@ -75,16 +61,16 @@ func unmarshalExpenseClaim(expenseClaimResponseBytes []byte) (*ExpenseClaims, er
 		return nil, err
 	}
 
-	err = expenseClaimResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return expenseClaimResponse, err
 }
 
 //Create will create expenseClaims given an ExpenseClaims struct
 func (e *ExpenseClaims) Create(provider *xerogolang.Provider, session goth.Session) (*ExpenseClaims, error) {
+	return e.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (e *ExpenseClaims) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*ExpenseClaims, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -95,7 +81,7 @@ func (e *ExpenseClaims) Create(provider *xerogolang.Provider, session goth.Sessi
 		return nil, err
 	}
 
-	expenseClaimResponseBytes, err := provider.Create(session, "ExpenseClaims", additionalHeaders, body)
+	expenseClaimResponseBytes, err := provider.CreateContext(ctx, session, "ExpenseClaims", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -106,6 +92,11 @@ func (e *ExpenseClaims) Create(provider *xerogolang.Provider, session goth.Sessi
 //Update will update an expenseClaim given an ExpenseClaims struct
 //This will only handle single expenseClaim - you cannot update multiple expenseClaims in a single call
 func (e *ExpenseClaims) Update(provider *xerogolang.Provider, session goth.Session) (*ExpenseClaims, error) {
+	return e.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (e *ExpenseClaims) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*ExpenseClaims, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -122,7 +113,7 @@ func (e *ExpenseClaims) Update(provider *xerogolang.Provider, session goth.Sessi
 		return nil, err
 	}
 
-	expenseClaimResponseBytes, err := provider.Update(session, "ExpenseClaims/"+e.ExpenseClaims[0].ExpenseClaimID, additionalHeaders, body)
+	expenseClaimResponseBytes, err := provider.UpdateContext(ctx, session, "ExpenseClaims/"+e.ExpenseClaims[0].ExpenseClaimID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +126,11 @@ func (e *ExpenseClaims) Update(provider *xerogolang.Provider, session goth.Sessi
 //If you need details then add a 'page' querystringParameter and get 100 ExpenseClaims at a time
 //additional querystringParameters such as where and order can be added as a map
 func FindExpenseClaimsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*ExpenseClaims, error) {
+	return FindExpenseClaimsModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindExpenseClaimsModifiedSinceContext is like FindExpenseClaimsModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindExpenseClaimsModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*ExpenseClaims, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -143,7 +139,7 @@ func FindExpenseClaimsModifiedSince(provider *xerogolang.Provider, session goth.
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	expenseClaimResponseBytes, err := provider.Find(session, "ExpenseClaims", additionalHeaders, querystringParameters)
+	expenseClaimResponseBytes, err := provider.FindContext(ctx, session, "ExpenseClaims", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -155,16 +151,26 @@ func FindExpenseClaimsModifiedSince(provider *xerogolang.Provider, session goth.
 //If you need details then add a 'page' querystringParameter and get 100 ExpenseClaims at a time
 //additional querystringParameters such as where and order can be added as a map
 func FindExpenseClaims(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*ExpenseClaims, error) {
-	return FindExpenseClaimsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindExpenseClaimsContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindExpenseClaimsContext is like FindExpenseClaims but uses ctx for the request so that it can be cancelled or given a deadline
+func FindExpenseClaimsContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*ExpenseClaims, error) {
+	return FindExpenseClaimsModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
 }
 
 //FindExpenseClaim will get a single expenseClaim - expenseClaimID can be a GUID for an expenseClaim or an expenseClaim number
 func FindExpenseClaim(provider *xerogolang.Provider, session goth.Session, expenseClaimID string) (*ExpenseClaims, error) {
+	return FindExpenseClaimContext(context.Background(), provider, session, expenseClaimID)
+}
+
+//FindExpenseClaimContext is like FindExpenseClaim but uses ctx for the request so that it can be cancelled or given a deadline
+func FindExpenseClaimContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, expenseClaimID string) (*ExpenseClaims, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	expenseClaimResponseBytes, err := provider.Find(session, "ExpenseClaims/"+expenseClaimID, additionalHeaders, nil)
+	expenseClaimResponseBytes, err := provider.FindContext(ctx, session, "ExpenseClaims/"+expenseClaimID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
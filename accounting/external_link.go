@@ -3,8 +3,8 @@ package accounting
 type ExternalLink struct {
 
 	// See External link types
-	LinkType string `json:"LinkType,omitempty"`
+	LinkType string `json:"LinkType,omitempty" xml:"LinkType,omitempty"`
 
 	// URL for service e.g. http://twitter.com/xeroapi
-	URL string `json:"Url,omitempty"`
+	URL string `json:"Url,omitempty" xml:"Url,omitempty"`
 }
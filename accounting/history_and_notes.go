@@ -1,21 +1,21 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
 //BankTransfer is a record of monies transferred from one bank account to another
 type HistoryRecord struct {
 
-  // The type of change recorded against the document
-  Changes string `json:"Changes,omitempty" xml:"-"`
+	// The type of change recorded against the document
+	Changes string `json:"Changes,omitempty" xml:"-"`
 
-  // UTC date that the history record was created
-	DateUTC string `json:"DateUTC,omitempty" xml:"-"`
+	// UTC date that the history record was created
+	DateUTC DateTime `json:"DateUTC,omitempty" xml:"-"`
 
 	// The user responsible for the change ("System Generated" when the change happens via API)
 	User string `json:"User,omitempty" xml:"-"`
@@ -29,19 +29,6 @@ type HistoryRecords struct {
 	HistoryRecords []HistoryRecord `json:"HistoryRecords" xml:"HistoryRecords"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (h *HistoryRecords) convertDates() error {
-	var err error
-	for n := len(h.HistoryRecords) - 1; n >= 0; n-- {
-		h.HistoryRecords[n].DateUTC, err = helpers.DotNetJSONTimeToRFC3339(h.HistoryRecords[n].DateUTC, false)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
 func unmarshalHistoryRecord(HistoryRecordResponseBytes []byte) (*HistoryRecords, error) {
 	var historyRecordResponse *HistoryRecords
 	err := json.Unmarshal(HistoryRecordResponseBytes, &historyRecordResponse)
@@ -49,16 +36,16 @@ func unmarshalHistoryRecord(HistoryRecordResponseBytes []byte) (*HistoryRecords,
 		return nil, err
 	}
 
-	err = historyRecordResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return historyRecordResponse, err
 }
 
 //Create will create History Records given a HistoryRecords struct and a docType and id
 func (h *HistoryRecords) Create(provider *xerogolang.Provider, session goth.Session, docType string, id string) (*HistoryRecords, error) {
+	return h.CreateContext(context.Background(), provider, session, docType, id)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (h *HistoryRecords) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, docType string, id string) (*HistoryRecords, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/json",
@@ -69,7 +56,7 @@ func (h *HistoryRecords) Create(provider *xerogolang.Provider, session goth.Sess
 		return nil, err
 	}
 
-	historyRecordResponseBytes, err := provider.Create(session, docType + "/" + id + "/history", additionalHeaders, body)
+	historyRecordResponseBytes, err := provider.CreateContext(ctx, session, docType+"/"+id+"/history", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -80,11 +67,16 @@ func (h *HistoryRecords) Create(provider *xerogolang.Provider, session goth.Sess
 //FindHistoryAndNotes gets all history items and notes for a given type and ID.
 //it is not supported on all endpoints.  See https://developer.xero.com/documentation/api/history-and-notes#SupportedDocs
 func FindHistoryAndNotes(provider *xerogolang.Provider, session goth.Session, docType string, id string) (*HistoryRecords, error) {
-  additionalHeaders := map[string]string{
+	return FindHistoryAndNotesContext(context.Background(), provider, session, docType, id)
+}
+
+//FindHistoryAndNotesContext is like FindHistoryAndNotes but uses ctx for the request so that it can be cancelled or given a deadline
+func FindHistoryAndNotesContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, docType string, id string) (*HistoryRecords, error) {
+	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	historyRecordResponseBytes, err := provider.Find(session, docType + "/" + id + "/history", additionalHeaders, nil)
+	historyRecordResponseBytes, err := provider.FindContext(ctx, session, docType+"/"+id+"/history", additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,19 +1,20 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
+	"io"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
 //Invoice is an Accounts Payable or Accounts Recievable document in a Xero organisation
 type Invoice struct {
 	// See Invoice Types
-	Type string `json:"Type" xml:"Type"`
+	Type InvoiceType `json:"Type" xml:"Type"`
 
 	// See Contacts
 	Contact Contact `json:"Contact" xml:"Contact"`
@@ -22,13 +23,13 @@ type Invoice struct {
 	LineItems []LineItem `json:"LineItems" xml:"LineItems>LineItem"`
 
 	// Date invoice was issued – YYYY-MM-DD. If the Date element is not specified it will default to the current date based on the timezone setting of the organisation
-	Date string `json:"DateString,omitempty" xml:"Date,omitempty"`
+	Date Date `json:"DateString,omitempty" xml:"Date,omitempty"`
 
 	// Date invoice is due – YYYY-MM-DD
-	DueDate string `json:"DueDateString,omitempty" xml:"DueDate,omitempty"`
+	DueDate Date `json:"DueDateString,omitempty" xml:"DueDate,omitempty"`
 
 	// Line amounts are exclusive of tax by default if you don’t specify this element. See Line Amount Types
-	LineAmountTypes string `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
 
 	// ACCREC – Unique alpha numeric code identifying invoice (when missing will auto-generate from your Organisation Invoice Settings) (max length = 255)
 	InvoiceNumber string `json:"InvoiceNumber,omitempty" xml:"InvoiceNumber,omitempty"`
@@ -46,31 +47,31 @@ type Invoice struct {
 	CurrencyCode string `json:"CurrencyCode,omitempty" xml:"CurrencyCode,omitempty"`
 
 	// The currency rate for a multicurrency invoice. If no rate is specified, the XE.com day rate is used. (max length = [18].[6])
-	CurrencyRate float64 `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
+	CurrencyRate Decimal `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
 
 	// See Invoice Status Codes
-	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
+	Status InvoiceStatus `json:"Status,omitempty" xml:"Status,omitempty"`
 
 	// Boolean to set whether the invoice in the Xero app should be marked as “sent”. This can be set only on invoices that have been approved
 	SentToContact bool `json:"SentToContact,omitempty" xml:"SentToContact,omitempty"`
 
 	// Shown on sales invoices (Accounts Receivable) when this has been set
-	ExpectedPaymentDate string `json:"ExpectedPaymentDate,omitempty" xml:"ExpectedPaymentDate,omitempty"`
+	ExpectedPaymentDate Date `json:"ExpectedPaymentDate,omitempty" xml:"ExpectedPaymentDate,omitempty"`
 
 	// Shown on bills (Accounts Payable) when this has been set
-	PlannedPaymentDate string `json:"PlannedPaymentDate,omitempty" xml:"PlannedPaymentDate,omitempty"`
+	PlannedPaymentDate Date `json:"PlannedPaymentDate,omitempty" xml:"PlannedPaymentDate,omitempty"`
 
 	// Total of invoice excluding taxes
-	SubTotal float64 `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
+	SubTotal Decimal `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
 
 	// Total tax on invoice
-	TotalTax float64 `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
+	TotalTax Decimal `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
 
 	// Total of Invoice tax inclusive (i.e. SubTotal + TotalTax). This will be ignored if it doesn’t equal the sum of the LineAmounts
-	Total float64 `json:"Total,omitempty" xml:"Total,omitempty"`
+	Total Decimal `json:"Total,omitempty" xml:"Total,omitempty"`
 
 	// Total of discounts applied on the invoice line items
-	TotalDiscount float64 `json:"TotalDiscount,omitempty" xml:"-"`
+	TotalDiscount Decimal `json:"TotalDiscount,omitempty" xml:"-"`
 
 	// Xero generated unique identifier for invoice
 	InvoiceID string `json:"InvoiceID,omitempty" xml:"InvoiceID,omitempty"`
@@ -88,19 +89,19 @@ type Invoice struct {
 	Overpayments *[]Overpayment `json:"Overpayments,omitempty" xml:"-"`
 
 	// Amount remaining to be paid on invoice
-	AmountDue float64 `json:"AmountDue,omitempty" xml:"-"`
+	AmountDue Decimal `json:"AmountDue,omitempty" xml:"-"`
 
 	// Sum of payments received for invoice
-	AmountPaid float64 `json:"AmountPaid,omitempty" xml:"-"`
+	AmountPaid Decimal `json:"AmountPaid,omitempty" xml:"-"`
 
 	// The date the invoice was fully paid. Only returned on fully paid invoices
-	FullyPaidOnDate string `json:"FullyPaidOnDate,omitempty" xml:"-"`
+	FullyPaidOnDate Date `json:"FullyPaidOnDate,omitempty" xml:"-"`
 
 	// Sum of all credit notes, over-payments and pre-payments applied to invoice
-	AmountCredited float64 `json:"AmountCredited,omitempty" xml:"-"`
+	AmountCredited Decimal `json:"AmountCredited,omitempty" xml:"-"`
 
 	// Last modified date UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"-"`
 
 	// Details of credit notes that have been applied to an invoice
 	CreditNotes *[]CreditNote `json:"CreditNotes,omitempty" xml:"-"`
@@ -115,20 +116,6 @@ var (
 	dayZero = time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
 )
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (i *Invoices) convertDates() error {
-	var err error
-	for n := len(i.Invoices) - 1; n >= 0; n-- {
-		i.Invoices[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(i.Invoices[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalInvoice(invoiceResponseBytes []byte) (*Invoices, error) {
 	var invoiceResponse *Invoices
 	err := json.Unmarshal(invoiceResponseBytes, &invoiceResponse)
@@ -136,27 +123,56 @@ func unmarshalInvoice(invoiceResponseBytes []byte) (*Invoices, error) {
 		return nil, err
 	}
 
-	err = invoiceResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return invoiceResponse, err
 }
 
+//Validate checks the invoices for problems Xero would reject them for, without sending them.
+//Fields that are only needed to create an invoice are checked when it has no InvoiceID.
+//Create and Update call it before making a request
+func (i *Invoices) Validate() error {
+	v := &validator{}
+	for n, invoice := range i.Invoices {
+		v.check(field("Invoices", n, "Type"), invoice.Type.Validate())
+		v.check(field("Invoices", n, "Status"), invoice.Status.Validate())
+		v.check(field("Invoices", n, "LineAmountTypes"), invoice.LineAmountTypes.Validate())
+		v.maxLength(field("Invoices", n, "InvoiceNumber"), invoice.InvoiceNumber, 255)
+		v.maxLength(field("Invoices", n, "Reference"), invoice.Reference, 255)
+		v.lineItems(field("Invoices", n, "LineItems"), invoice.LineItems, invoice.Type == "" || invoice.Type == InvoiceTypeAccRec)
+
+		if invoice.InvoiceID == "" {
+			v.required(field("Invoices", n, "Type"), invoice.Type != "")
+			v.contact(field("Invoices", n, "Contact"), invoice.Contact)
+			if invoice.Status == InvoiceStatusSubmitted || invoice.Status == InvoiceStatusAuthorised {
+				v.required(field("Invoices", n, "LineItems"), len(invoice.LineItems) > 0)
+			}
+		}
+	}
+	return v.err()
+}
+
 //Create will create invoices given an Invoices struct
 func (i *Invoices) Create(provider *xerogolang.Provider, session goth.Session) (*Invoices, error) {
+	return i.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (i *Invoices) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Invoices, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := i.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body, err := xml.MarshalIndent(i, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	invoiceResponseBytes, err := provider.Create(session, "Invoices", additionalHeaders, body)
+	invoiceResponseBytes, err := provider.CreateContext(ctx, session, "Invoices", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -167,17 +183,27 @@ func (i *Invoices) Create(provider *xerogolang.Provider, session goth.Session) (
 //Update will update an invoice given an Invoices struct
 //This will only handle single invoice - you cannot update multiple invoices in a single call
 func (i *Invoices) Update(provider *xerogolang.Provider, session goth.Session) (*Invoices, error) {
+	return i.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (i *Invoices) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Invoices, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := i.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body, err := xml.MarshalIndent(i, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	invoiceResponseBytes, err := provider.Update(session, "Invoices/"+i.Invoices[0].InvoiceID, additionalHeaders, body)
+	invoiceResponseBytes, err := provider.UpdateContext(ctx, session, "Invoices/"+i.Invoices[0].InvoiceID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -190,6 +216,11 @@ func (i *Invoices) Update(provider *xerogolang.Provider, session goth.Session) (
 //If you need details then add a 'page' querystringParameter and get 100 Invoices at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindInvoicesModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Invoices, error) {
+	return FindInvoicesModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindInvoicesModifiedSinceContext is like FindInvoicesModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindInvoicesModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Invoices, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -198,7 +229,7 @@ func FindInvoicesModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	invoiceResponseBytes, err := provider.Find(session, "Invoices", additionalHeaders, querystringParameters)
+	invoiceResponseBytes, err := provider.FindContext(ctx, session, "Invoices", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -210,16 +241,36 @@ func FindInvoicesModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 //If you need details then add a 'page' querystringParameter and get 100 Invoices at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindInvoices(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Invoices, error) {
-	return FindInvoicesModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindInvoicesContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindInvoicesContext is like FindInvoices but uses ctx for the request so that it can be cancelled or given a deadline
+func FindInvoicesContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Invoices, error) {
+	return FindInvoicesModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//IterateInvoices returns an Iterator over every invoice matching querystringParameters
+func IterateInvoices(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) *Iterator[Invoice] {
+	return iterate(ctx, provider, session, querystringParameters, FindInvoicesContext, func(invoices *Invoices) []Invoice { return invoices.Invoices })
+}
+
+//ForEachInvoice calls fn with every invoice matching querystringParameters, stopping at the first error fn returns
+func ForEachInvoice(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string, fn func(invoice Invoice) error) error {
+	return ForEach(IterateInvoices(ctx, provider, session, querystringParameters), fn)
 }
 
 //FindInvoice will get a single invoice - invoiceID can be a GUID for an invoice or an invoice number
 func FindInvoice(provider *xerogolang.Provider, session goth.Session, invoiceID string) (*Invoices, error) {
+	return FindInvoiceContext(context.Background(), provider, session, invoiceID)
+}
+
+//FindInvoiceContext is like FindInvoice but uses ctx for the request so that it can be cancelled or given a deadline
+func FindInvoiceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, invoiceID string) (*Invoices, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	invoiceResponseBytes, err := provider.Find(session, "Invoices/"+invoiceID, additionalHeaders, nil)
+	invoiceResponseBytes, err := provider.FindContext(ctx, session, "Invoices/"+invoiceID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -227,23 +278,38 @@ func FindInvoice(provider *xerogolang.Provider, session goth.Session, invoiceID
 	return unmarshalInvoice(invoiceResponseBytes)
 }
 
+//FindInvoicePDF gets the PDF of a single invoice as Xero renders it - invoiceID can be a GUID for an invoice or an invoice number.
+//The PDF is streamed rather than read into memory and the caller must close the returned reader
+func FindInvoicePDF(provider *xerogolang.Provider, session goth.Session, invoiceID string) (io.ReadCloser, error) {
+	return FindInvoicePDFContext(context.Background(), provider, session, invoiceID)
+}
+
+//FindInvoicePDFContext is like FindInvoicePDF but uses ctx for the request so that it can be cancelled or given a deadline
+func FindInvoicePDFContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, invoiceID string) (io.ReadCloser, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/pdf",
+	}
+
+	return provider.FindStreamContext(ctx, session, "Invoices/"+invoiceID, additionalHeaders, nil)
+}
+
 //GenerateExampleInvoice Creates an Example invoice
 func GenerateExampleInvoice() *Invoices {
 	lineItem := LineItem{
 		Description: "Importing & Exporting Services",
-		Quantity:    1.00,
-		UnitAmount:  395.00,
+		Quantity:    MustParseDecimal("1.00"),
+		UnitAmount:  MustParseDecimal("395.00"),
 		AccountCode: "200",
 	}
 
 	invoice := Invoice{
-		Type: "ACCREC",
+		Type: InvoiceTypeAccRec,
 		Contact: Contact{
 			Name: "George Costanza",
 		},
-		Date:            helpers.TodayRFC3339(),
-		DueDate:         helpers.FormatDate(time.Now().Add(720 * time.Hour)),
-		LineAmountTypes: "Exclusive",
+		Date:            Today(),
+		DueDate:         DateOf(time.Now().Add(720 * time.Hour)),
+		LineAmountTypes: LineAmountExclusive,
 		LineItems:       []LineItem{},
 	}
 
@@ -0,0 +1,133 @@
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/markbates/goth"
+)
+
+var (
+	//ErrInvoiceNotApproved is the reason Xero won't email an invoice, or give its online URL, while it is a draft or awaiting approval
+	ErrInvoiceNotApproved = errors.New("the invoice must be approved")
+
+	//ErrContactHasNoEmail is the reason Xero won't email an invoice whose contact has no email address
+	ErrContactHasNoEmail = errors.New("the contact has no email address")
+
+	//ErrNotSalesInvoice is the reason Xero won't email an ACCPAY invoice or give its online URL
+	ErrNotSalesInvoice = errors.New("only ACCREC invoices can be sent")
+)
+
+//InvoiceSendError is returned by EmailInvoice and FindOnlineInvoiceURL when Xero refuses for a common reason.
+//Use errors.Is with ErrInvoiceNotApproved, ErrContactHasNoEmail or ErrNotSalesInvoice to check which one.
+//The *xerogolang.APIError returned by Xero can be got at with errors.As
+type InvoiceSendError struct {
+	//Reason is one of ErrInvoiceNotApproved, ErrContactHasNoEmail or ErrNotSalesInvoice
+	Reason error
+
+	//Err is the error returned by Xero
+	Err error
+}
+
+func (e *InvoiceSendError) Error() string {
+	return e.Reason.Error() + ": " + e.Err.Error()
+}
+
+//Unwrap allows errors.Is and errors.As to match both the Reason and the error returned by Xero
+func (e *InvoiceSendError) Unwrap() []error {
+	return []error{e.Reason, e.Err}
+}
+
+//invoiceSendReasons maps the validation messages Xero returns when an invoice can't be sent to the reason for it.
+//They are matched in full, ignoring case and a trailing full stop, so other messages that happen to mention
+//a status or an invoice type aren't mistaken for them
+var invoiceSendReasons = map[string]error{
+	"invoice not of valid status for sending by email": ErrInvoiceNotApproved,
+	"the contact does not have a valid email address":  ErrContactHasNoEmail,
+	"only invoices of type accrec can be sent":         ErrNotSalesInvoice,
+}
+
+//invoiceSendError recognises the validation messages Xero returns when an invoice can't be sent and wraps err in an InvoiceSendError.
+//Other errors are returned unchanged
+func invoiceSendError(err error) error {
+	var apiError *xerogolang.APIError
+	if !errors.As(err, &apiError) || !xerogolang.IsValidationError(err) {
+		return err
+	}
+
+	messages := []string{apiError.Message}
+	for _, validationError := range apiError.ValidationErrors {
+		messages = append(messages, validationError.Message)
+	}
+	for _, message := range messages {
+		message = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(message), "."))
+		if reason, ok := invoiceSendReasons[message]; ok {
+			return &InvoiceSendError{Reason: reason, Err: err}
+		}
+	}
+	return err
+}
+
+//EmailInvoice asks Xero to email an ACCREC invoice to its contact, as the "Email" action in the Xero app does.
+//The invoice must be approved and its contact must have an email address.
+//Xero marks the invoice as sent to the contact
+func EmailInvoice(provider *xerogolang.Provider, session goth.Session, invoiceID string) error {
+	return EmailInvoiceContext(context.Background(), provider, session, invoiceID)
+}
+
+//EmailInvoiceContext is like EmailInvoice but uses ctx for the request so that it can be cancelled or given a deadline
+func EmailInvoiceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, invoiceID string) error {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/json",
+	}
+
+	_, err := provider.UpdateContext(ctx, session, "Invoices/"+invoiceID+"/Email", additionalHeaders, []byte("{}"))
+	if err != nil {
+		return invoiceSendError(err)
+	}
+
+	return nil
+}
+
+//OnlineInvoice is the link to the online version of an invoice that a customer can view and pay
+type OnlineInvoice struct {
+	// The URL of the online invoice
+	OnlineInvoiceURL string `json:"OnlineInvoiceUrl,omitempty"`
+}
+
+//OnlineInvoices contains a collection of OnlineInvoices
+type OnlineInvoices struct {
+	OnlineInvoices []OnlineInvoice `json:"OnlineInvoices"`
+}
+
+//FindOnlineInvoiceURL gets the URL of the online version of an approved ACCREC invoice
+func FindOnlineInvoiceURL(provider *xerogolang.Provider, session goth.Session, invoiceID string) (string, error) {
+	return FindOnlineInvoiceURLContext(context.Background(), provider, session, invoiceID)
+}
+
+//FindOnlineInvoiceURLContext is like FindOnlineInvoiceURL but uses ctx for the request so that it can be cancelled or given a deadline
+func FindOnlineInvoiceURLContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, invoiceID string) (string, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	onlineInvoiceResponseBytes, err := provider.FindContext(ctx, session, "Invoices/"+invoiceID+"/OnlineInvoice", additionalHeaders, nil)
+	if err != nil {
+		return "", invoiceSendError(err)
+	}
+
+	var onlineInvoiceResponse OnlineInvoices
+	err = json.Unmarshal(onlineInvoiceResponseBytes, &onlineInvoiceResponse)
+	if err != nil {
+		return "", err
+	}
+	if len(onlineInvoiceResponse.OnlineInvoices) == 0 {
+		return "", errors.New("Xero did not return an online invoice")
+	}
+
+	return onlineInvoiceResponse.OnlineInvoices[0].OnlineInvoiceURL, nil
+}
@@ -0,0 +1,83 @@
+package accounting
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+//invoiceServer responds to requests for the invoice with the given ID and rejects any other invoice with message
+func invoiceServer(invoiceID string, message string, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api.xro/2.0/Invoices/" + invoiceID + "/Email":
+			w.WriteHeader(http.StatusNoContent)
+		case "/api.xro/2.0/Invoices/" + invoiceID + "/OnlineInvoice":
+			io.WriteString(w, `{"OnlineInvoices": [{"OnlineInvoiceUrl": "https://in.xero.com/abc"}]}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"ErrorNumber": 10, "Type": "ValidationException", "Message": "A validation exception occurred", "Elements": [{"ValidationErrors": [{"Message": "`+message+`"}]}]}`)
+		}
+	}))
+}
+
+func Test_EmailInvoice(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	ts := invoiceServer("invoice-id", "", &requests)
+	defer ts.Close()
+	provider, session := testProvider(t, ts)
+
+	a.NoError(EmailInvoice(provider, session, "invoice-id"))
+
+	url, err := FindOnlineInvoiceURL(provider, session, "invoice-id")
+	a.NoError(err)
+	a.Equal("https://in.xero.com/abc", url)
+
+	a.Equal([]string{
+		"POST /api.xro/2.0/Invoices/invoice-id/Email",
+		"GET /api.xro/2.0/Invoices/invoice-id/OnlineInvoice",
+	}, requests)
+}
+
+func Test_EmailInvoice_Errors(t *testing.T) {
+	t.Parallel()
+
+	for message, reason := range map[string]error{
+		"Invoice not of valid status for sending by email":        ErrInvoiceNotApproved,
+		"Invoice not of valid status for sending by email.":       ErrInvoiceNotApproved,
+		"The contact does not have a valid email address":         ErrContactHasNoEmail,
+		"Only invoices of type ACCREC can be sent":                ErrNotSalesInvoice,
+		"Something else went wrong with the request entirely":     nil,
+		"Status must be one of DRAFT, SUBMITTED or AUTHORISED":    nil,
+		"Account code 200 can not be used on ACCPAY invoices":     nil,
+		"The email address of the contact person is not a valid": nil,
+	} {
+		var requests []string
+		ts := invoiceServer("invoice-id", message, &requests)
+		provider, session := testProvider(t, ts)
+
+		err := EmailInvoice(provider, session, "other-invoice-id")
+		ts.Close()
+
+		assert.Error(t, err, message)
+		assert.True(t, xerogolang.IsValidationError(err), message)
+		var sendError *InvoiceSendError
+		if reason == nil {
+			assert.False(t, errors.As(err, &sendError), message)
+			continue
+		}
+		assert.True(t, errors.Is(err, reason), message)
+		assert.True(t, errors.As(err, &sendError), message)
+		assert.Equal(t, reason, sendError.Reason, message)
+	}
+}
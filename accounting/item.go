@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -44,13 +44,13 @@ type Item struct {
 	IsTrackedAsInventory bool `json:"IsTrackedAsInventory,omitempty" xml:"-"`
 
 	// The value of the item on hand. Calculated using average cost accounting.
-	TotalCostPool float64 `json:"TotalCostPool,omitempty" xml:"-"`
+	TotalCostPool Decimal `json:"TotalCostPool,omitempty" xml:"-"`
 
 	// The quantity of the item on hand
-	QuantityOnHand float64 `json:"QuantityOnHand,omitempty" xml:"-"`
+	QuantityOnHand Decimal `json:"QuantityOnHand,omitempty" xml:"-"`
 
 	// Last modified date in UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"-"`
 
 	// The Xero identifier for an Item
 	ItemID string `json:"ItemID,omitempty" xml:"ItemID,omitempty"`
@@ -64,7 +64,7 @@ type Items struct {
 //PurchaseAndSaleDetails are Elements for Purchases and Sales
 type PurchaseAndSaleDetails struct {
 	//Unit Price of the item. By default UnitPrice is returned to two decimal places.  You can use 4 decimal places by adding the unitdp=4 querystring parameter to your request.
-	UnitPrice float64 `json:"UnitPrice,omitempty" xml:"UnitPrice,omitempty"`
+	UnitPrice Decimal `json:"UnitPrice,omitempty" xml:"UnitPrice,omitempty"`
 
 	//Default account code to be used for purchased/sale. Not applicable to the purchase details of tracked items
 	AccountCode string `json:"AccountCode,omitempty" xml:"AccountCode,omitempty"`
@@ -76,20 +76,6 @@ type PurchaseAndSaleDetails struct {
 	TaxType string `json:"TaxType,omitempty" xml:"TaxType,omitempty"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (i *Items) convertDates() error {
-	var err error
-	for n := len(i.Items) - 1; n >= 0; n-- {
-		i.Items[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(i.Items[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalItem(itemResponseBytes []byte) (*Items, error) {
 	var itemResponse *Items
 	err := json.Unmarshal(itemResponseBytes, &itemResponse)
@@ -97,16 +83,16 @@ func unmarshalItem(itemResponseBytes []byte) (*Items, error) {
 		return nil, err
 	}
 
-	err = itemResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return itemResponse, err
 }
 
 //Create will create items given an Items struct
 func (i *Items) Create(provider *xerogolang.Provider, session goth.Session) (*Items, error) {
+	return i.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (i *Items) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Items, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -117,7 +103,7 @@ func (i *Items) Create(provider *xerogolang.Provider, session goth.Session) (*It
 		return nil, err
 	}
 
-	itemResponseBytes, err := provider.Create(session, "Items", additionalHeaders, body)
+	itemResponseBytes, err := provider.CreateContext(ctx, session, "Items", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -128,6 +114,11 @@ func (i *Items) Create(provider *xerogolang.Provider, session goth.Session) (*It
 //Update will update an item given an Items struct
 //This will only handle single item - you cannot update multiple items in a single call
 func (i *Items) Update(provider *xerogolang.Provider, session goth.Session) (*Items, error) {
+	return i.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (i *Items) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Items, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -138,7 +129,7 @@ func (i *Items) Update(provider *xerogolang.Provider, session goth.Session) (*It
 		return nil, err
 	}
 
-	itemResponseBytes, err := provider.Update(session, "Items/"+i.Items[0].ItemID, additionalHeaders, body)
+	itemResponseBytes, err := provider.UpdateContext(ctx, session, "Items/"+i.Items[0].ItemID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -149,6 +140,11 @@ func (i *Items) Update(provider *xerogolang.Provider, session goth.Session) (*It
 //FindItemsModifiedSince will get all items modified after a specified date.
 //additional querystringParameters such as where, page, order can be added as a map
 func FindItemsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Items, error) {
+	return FindItemsModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindItemsModifiedSinceContext is like FindItemsModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindItemsModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Items, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -157,7 +153,7 @@ func FindItemsModifiedSince(provider *xerogolang.Provider, session goth.Session,
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	itemResponseBytes, err := provider.Find(session, "Items", additionalHeaders, querystringParameters)
+	itemResponseBytes, err := provider.FindContext(ctx, session, "Items", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -167,16 +163,26 @@ func FindItemsModifiedSince(provider *xerogolang.Provider, session goth.Session,
 
 //FindItems will get all items.
 func FindItems(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Items, error) {
-	return FindItemsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindItemsContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindItemsContext is like FindItems but uses ctx for the request so that it can be cancelled or given a deadline
+func FindItemsContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Items, error) {
+	return FindItemsModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
 }
 
 //FindItem will get a single item - itemID must be a GUID for an item
 func FindItem(provider *xerogolang.Provider, session goth.Session, itemID string) (*Items, error) {
+	return FindItemContext(context.Background(), provider, session, itemID)
+}
+
+//FindItemContext is like FindItem but uses ctx for the request so that it can be cancelled or given a deadline
+func FindItemContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, itemID string) (*Items, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	itemResponseBytes, err := provider.Find(session, "Items/"+itemID, additionalHeaders, nil)
+	itemResponseBytes, err := provider.FindContext(ctx, session, "Items/"+itemID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -186,11 +192,16 @@ func FindItem(provider *xerogolang.Provider, session goth.Session, itemID string
 
 //RemoveItem will get a single item - itemID must be a GUID for an item
 func RemoveItem(provider *xerogolang.Provider, session goth.Session, itemID string) (*Items, error) {
+	return RemoveItemContext(context.Background(), provider, session, itemID)
+}
+
+//RemoveItemContext is like RemoveItem but uses ctx for the request so that it can be cancelled or given a deadline
+func RemoveItemContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, itemID string) (*Items, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	itemResponseBytes, err := provider.Remove(session, "Items/"+itemID, additionalHeaders)
+	itemResponseBytes, err := provider.RemoveContext(ctx, session, "Items/"+itemID, additionalHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -208,11 +219,11 @@ func GenerateExampleItem() *Items {
 		IsSold:              true,
 		IsPurchased:         true,
 		PurchaseDetails: PurchaseAndSaleDetails{
-			UnitPrice:   140.00,
+			UnitPrice:   MustParseDecimal("140.00"),
 			AccountCode: "300",
 		},
 		SalesDetails: PurchaseAndSaleDetails{
-			UnitPrice:   300.00,
+			UnitPrice:   MustParseDecimal("300.00"),
 			AccountCode: "200",
 		},
 	}
@@ -1,11 +1,11 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -16,13 +16,13 @@ type Journal struct {
 	JournalID string `json:"JournalID,omitempty" xml:"JournalID,omitempty"`
 
 	// Date the journal was posted
-	JournalDate string `json:"JournalDate,omitempty" xml:"JournalDate,omitempty"`
+	JournalDate Date `json:"JournalDate,omitempty" xml:"JournalDate,omitempty"`
 
 	// Xero generated journal number
 	JournalNumber int `json:"JournalNumber,omitempty" xml:"JournalNumber,omitempty"`
 
 	// Created date UTC format
-	CreatedDateUTC string `json:"CreatedDateUTC,omitempty" xml:"CreatedDateUTC,omitempty"`
+	CreatedDateUTC DateTime `json:"CreatedDateUTC,omitempty" xml:"CreatedDateUTC,omitempty"`
 
 	//
 	Reference string `json:"Reference,omitempty" xml:"Reference,omitempty"`
@@ -42,24 +42,6 @@ type Journals struct {
 	Journals []Journal `json:"Journals,omitempty" xml:"Journal,omitempty"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (j *Journals) convertDates() error {
-	var err error
-	for n := len(j.Journals) - 1; n >= 0; n-- {
-		j.Journals[n].JournalDate, err = helpers.DotNetJSONTimeToRFC3339(j.Journals[n].JournalDate, false)
-		if err != nil {
-			return err
-		}
-		j.Journals[n].CreatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(j.Journals[n].CreatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalJournals(journalResponseBytes []byte) (*Journals, error) {
 	var journalResponse *Journals
 	err := json.Unmarshal(journalResponseBytes, &journalResponse)
@@ -67,11 +49,6 @@ func unmarshalJournals(journalResponseBytes []byte) (*Journals, error) {
 		return nil, err
 	}
 
-	err = journalResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return journalResponse, err
 }
 
@@ -81,6 +58,11 @@ func unmarshalJournals(journalResponseBytes []byte) (*Journals, error) {
 //Journals are ordered oldest to newest.
 //additional querystringParameters such as offset and paymentsOnly can be added as a map
 func FindJournalsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Journals, error) {
+	return FindJournalsModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindJournalsModifiedSinceContext is like FindJournalsModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindJournalsModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Journals, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -89,7 +71,7 @@ func FindJournalsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	journalResponseBytes, err := provider.Find(session, "Journals", additionalHeaders, querystringParameters)
+	journalResponseBytes, err := provider.FindContext(ctx, session, "Journals", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -103,16 +85,26 @@ func FindJournalsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 //Journals are ordered oldest to newest.
 //additional querystringParameters such as offset and paymentsOnly can be added as a map
 func FindJournals(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Journals, error) {
-	return FindJournalsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindJournalsContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindJournalsContext is like FindJournals but uses ctx for the request so that it can be cancelled or given a deadline
+func FindJournalsContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Journals, error) {
+	return FindJournalsModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
 }
 
 //FindJournal will get a single journal - journalID can be a GUID for an journal or an journal number
 func FindJournal(provider *xerogolang.Provider, session goth.Session, journalID string) (*Journals, error) {
+	return FindJournalContext(context.Background(), provider, session, journalID)
+}
+
+//FindJournalContext is like FindJournal but uses ctx for the request so that it can be cancelled or given a deadline
+func FindJournalContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, journalID string) (*Journals, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	journalResponseBytes, err := provider.Find(session, "Journals/"+journalID, additionalHeaders, nil)
+	journalResponseBytes, err := provider.FindContext(ctx, session, "Journals/"+journalID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
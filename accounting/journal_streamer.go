@@ -0,0 +1,92 @@
+package accounting
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/markbates/goth"
+)
+
+//JournalStreamer reads every journal after a JournalNumber, oldest first, using the offset
+//parameter of the Journals endpoint to fetch them 100 at a time.
+//Offset is updated as journals are handled so that a long export can save it and resume where it left off
+type JournalStreamer struct {
+	Provider *xerogolang.Provider
+	Session  goth.Session
+
+	//Offset is the JournalNumber to start after. Zero starts from the first journal.
+	//It is updated to the JournalNumber of each journal once it has been handled without error
+	Offset int
+
+	//PaymentsOnly limits the journals to cash transactions
+	PaymentsOnly bool
+
+	//ModifiedSince, if set, limits the journals to those created or modified since this time
+	ModifiedSince time.Time
+
+	//Checkpoint, if set, is called with Offset after each page of journals has been handled.
+	//Returning an error stops the stream
+	Checkpoint func(offset int) error
+}
+
+//NewJournalStreamer returns a streamer that reads the journals after offset
+func NewJournalStreamer(provider *xerogolang.Provider, session goth.Session, offset int) *JournalStreamer {
+	return &JournalStreamer{
+		Provider: provider,
+		Session:  session,
+		Offset:   offset,
+	}
+}
+
+//Stream calls fn with each journal in JournalNumber order until there are none left,
+//ctx is cancelled, or fn or Checkpoint return an error
+func (s *JournalStreamer) Stream(ctx context.Context, fn func(journal Journal) error) error {
+	modifiedSince := dayZero
+	if !s.ModifiedSince.IsZero() {
+		modifiedSince = s.ModifiedSince
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		querystringParameters := map[string]string{
+			"offset": strconv.Itoa(s.Offset),
+		}
+		if s.PaymentsOnly {
+			querystringParameters["paymentsOnly"] = "true"
+		}
+
+		journals, err := FindJournalsModifiedSinceContext(ctx, s.Provider, s.Session, modifiedSince, querystringParameters)
+		if err != nil {
+			return err
+		}
+
+		for _, journal := range journals.Journals {
+			//The offset has to move forward or we would fetch the same page forever
+			if journal.JournalNumber <= s.Offset {
+				return fmt.Errorf("journal %d was returned after offset %d", journal.JournalNumber, s.Offset)
+			}
+			err = fn(journal)
+			if err != nil {
+				return err
+			}
+			s.Offset = journal.JournalNumber
+		}
+
+		if len(journals.Journals) > 0 && s.Checkpoint != nil {
+			err = s.Checkpoint(s.Offset)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(journals.Journals) < pageSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/mrjones/oauth"
+	"github.com/stretchr/testify/assert"
+)
+
+//journalServer serves journals numbered 1 to total through the offset parameter
+func journalServer(total int, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.Query().Encode())
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		journals := Journals{Journals: []Journal{}}
+		for number := offset + 1; number <= total && len(journals.Journals) < pageSize; number++ {
+			journals.Journals = append(journals.Journals, Journal{JournalNumber: number})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(journals)
+	}))
+}
+
+//testProvider returns a provider that sends its requests to ts and a session to use with it
+func testProvider(t *testing.T, ts *httptest.Server) (*xerogolang.Provider, *xerogolang.Session) {
+	provider, err := xerogolang.NewProvider(
+		xerogolang.WithCredentials("KEY", "SECRET"),
+		xerogolang.WithMethod("public"),
+		xerogolang.WithBaseURL(ts.URL),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider, &xerogolang.Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+}
+
+func journalStreamer(t *testing.T, ts *httptest.Server, offset int) *JournalStreamer {
+	provider, session := testProvider(t, ts)
+	return NewJournalStreamer(provider, session, offset)
+}
+
+func Test_JournalStreamer(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	ts := journalServer(230, &requests)
+	defer ts.Close()
+
+	streamer := journalStreamer(t, ts, 0)
+	streamer.PaymentsOnly = true
+	var checkpoints []int
+	streamer.Checkpoint = func(offset int) error {
+		checkpoints = append(checkpoints, offset)
+		return nil
+	}
+
+	var numbers []int
+	err := streamer.Stream(context.Background(), func(journal Journal) error {
+		numbers = append(numbers, journal.JournalNumber)
+		return nil
+	})
+	a.NoError(err)
+	a.Len(numbers, 230)
+	a.Equal(1, numbers[0])
+	a.Equal(230, numbers[229])
+	a.Equal(230, streamer.Offset)
+	a.Equal([]int{100, 200, 230}, checkpoints)
+	a.Equal([]string{"offset=0&paymentsOnly=true", "offset=100&paymentsOnly=true", "offset=200&paymentsOnly=true"}, requests)
+}
+
+func Test_JournalStreamer_Resume(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	ts := journalServer(150, &requests)
+	defer ts.Close()
+
+	failure := errors.New("disk full")
+	streamer := journalStreamer(t, ts, 0)
+	err := streamer.Stream(context.Background(), func(journal Journal) error {
+		if journal.JournalNumber == 42 {
+			return failure
+		}
+		return nil
+	})
+	a.Equal(failure, err)
+	a.Equal(41, streamer.Offset, "the failed journal must be handled again when resuming")
+
+	resumed := journalStreamer(t, ts, streamer.Offset)
+	var numbers []int
+	err = resumed.Stream(context.Background(), func(journal Journal) error {
+		numbers = append(numbers, journal.JournalNumber)
+		return nil
+	})
+	a.NoError(err)
+	a.Equal(42, numbers[0])
+	a.Equal(150, resumed.Offset)
+	a.Equal("offset=41", requests[1])
+}
@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -39,7 +39,7 @@ type LinkedTransaction struct {
 	Type string `json:"Type,omitempty" xml:"Type,omitempty"`
 
 	// The last modified date in UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"-"`
 
 	// The Type of the source tranasction. This will be ACCPAY if the linked transaction was created from an invoice and SPEND if it was created from a bank transaction.
 	SourceTransactionTypeCode string `json:"SourceTransactionTypeCode,omitempty" xml:"SourceTransactionTypeCode,omitempty"`
@@ -50,20 +50,6 @@ type LinkedTransactions struct {
 	LinkedTransactions []LinkedTransaction `json:"LinkedTransactions" xml:"LinkedTransaction"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (l *LinkedTransactions) convertDates() error {
-	var err error
-	for n := len(l.LinkedTransactions) - 1; n >= 0; n-- {
-		l.LinkedTransactions[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(l.LinkedTransactions[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalLinkedTransaction(linkedTransactionResponseBytes []byte) (*LinkedTransactions, error) {
 	var linkedTransactionResponse *LinkedTransactions
 	err := json.Unmarshal(linkedTransactionResponseBytes, &linkedTransactionResponse)
@@ -71,16 +57,16 @@ func unmarshalLinkedTransaction(linkedTransactionResponseBytes []byte) (*LinkedT
 		return nil, err
 	}
 
-	err = linkedTransactionResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return linkedTransactionResponse, err
 }
 
 //Create will create LinkedTransactions given an LinkedTransactions struct
 func (l *LinkedTransactions) Create(provider *xerogolang.Provider, session goth.Session) (*LinkedTransactions, error) {
+	return l.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (l *LinkedTransactions) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*LinkedTransactions, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -91,7 +77,7 @@ func (l *LinkedTransactions) Create(provider *xerogolang.Provider, session goth.
 		return nil, err
 	}
 
-	linkedTransactionResponseBytes, err := provider.Create(session, "LinkedTransactions", additionalHeaders, body)
+	linkedTransactionResponseBytes, err := provider.CreateContext(ctx, session, "LinkedTransactions", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -103,6 +89,11 @@ func (l *LinkedTransactions) Create(provider *xerogolang.Provider, session goth.
 //This will only handle single LinkedTransaction - you cannot update multiple LinkedTransactions in a single call
 //LinkedTransactions cannot be modified, only created and deleted.
 func (l *LinkedTransactions) Update(provider *xerogolang.Provider, session goth.Session) (*LinkedTransactions, error) {
+	return l.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (l *LinkedTransactions) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*LinkedTransactions, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -113,7 +104,7 @@ func (l *LinkedTransactions) Update(provider *xerogolang.Provider, session goth.
 		return nil, err
 	}
 
-	LinkedTransactionResponseBytes, err := provider.Update(session, "LinkedTransactions/"+l.LinkedTransactions[0].LinkedTransactionID, additionalHeaders, body)
+	LinkedTransactionResponseBytes, err := provider.UpdateContext(ctx, session, "LinkedTransactions/"+l.LinkedTransactions[0].LinkedTransactionID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -125,6 +116,11 @@ func (l *LinkedTransactions) Update(provider *xerogolang.Provider, session goth.
 //additional querystringParameters such as page, SourceTransactionID, ContactID,
 //Status, and TargetTransactionID can be added as a map
 func FindLinkedTransactionsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*LinkedTransactions, error) {
+	return FindLinkedTransactionsModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindLinkedTransactionsModifiedSinceContext is like FindLinkedTransactionsModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindLinkedTransactionsModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*LinkedTransactions, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -133,7 +129,7 @@ func FindLinkedTransactionsModifiedSince(provider *xerogolang.Provider, session
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	linkedTransactionResponseBytes, err := provider.Find(session, "LinkedTransactions", additionalHeaders, querystringParameters)
+	linkedTransactionResponseBytes, err := provider.FindContext(ctx, session, "LinkedTransactions", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -145,16 +141,36 @@ func FindLinkedTransactionsModifiedSince(provider *xerogolang.Provider, session
 //additional querystringParameters such as page, SourceTransactionID, ContactID,
 //Status, and TargetTransactionID can be added as a map
 func FindLinkedTransactions(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*LinkedTransactions, error) {
-	return FindLinkedTransactionsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindLinkedTransactionsContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindLinkedTransactionsContext is like FindLinkedTransactions but uses ctx for the request so that it can be cancelled or given a deadline
+func FindLinkedTransactionsContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*LinkedTransactions, error) {
+	return FindLinkedTransactionsModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//IterateLinkedTransactions returns an Iterator over every linked transaction matching querystringParameters
+func IterateLinkedTransactions(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) *Iterator[LinkedTransaction] {
+	return iterate(ctx, provider, session, querystringParameters, FindLinkedTransactionsContext, func(linkedTransactions *LinkedTransactions) []LinkedTransaction { return linkedTransactions.LinkedTransactions })
+}
+
+//ForEachLinkedTransaction calls fn with every linked transaction matching querystringParameters, stopping at the first error fn returns
+func ForEachLinkedTransaction(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string, fn func(linkedTransaction LinkedTransaction) error) error {
+	return ForEach(IterateLinkedTransactions(ctx, provider, session, querystringParameters), fn)
 }
 
 //FindLinkedTransaction will get a single LinkedTransaction - LinkedTransactionID must be a GUID for an LinkedTransaction
 func FindLinkedTransaction(provider *xerogolang.Provider, session goth.Session, linkedTransactionID string) (*LinkedTransactions, error) {
+	return FindLinkedTransactionContext(context.Background(), provider, session, linkedTransactionID)
+}
+
+//FindLinkedTransactionContext is like FindLinkedTransaction but uses ctx for the request so that it can be cancelled or given a deadline
+func FindLinkedTransactionContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, linkedTransactionID string) (*LinkedTransactions, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	linkedTransactionResponseBytes, err := provider.Find(session, "LinkedTransactions/"+linkedTransactionID, additionalHeaders, nil)
+	linkedTransactionResponseBytes, err := provider.FindContext(ctx, session, "LinkedTransactions/"+linkedTransactionID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -164,11 +180,16 @@ func FindLinkedTransaction(provider *xerogolang.Provider, session goth.Session,
 
 //RemoveLinkedTransaction will get a single LinkedTransaction - LinkedTransactionID must be a GUID for an LinkedTransaction
 func RemoveLinkedTransaction(provider *xerogolang.Provider, session goth.Session, linkedTransactionID string) (*LinkedTransactions, error) {
+	return RemoveLinkedTransactionContext(context.Background(), provider, session, linkedTransactionID)
+}
+
+//RemoveLinkedTransactionContext is like RemoveLinkedTransaction but uses ctx for the request so that it can be cancelled or given a deadline
+func RemoveLinkedTransactionContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, linkedTransactionID string) (*LinkedTransactions, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	linkedTransactionResponseBytes, err := provider.Remove(session, "LinkedTransactions/"+linkedTransactionID, additionalHeaders)
+	linkedTransactionResponseBytes, err := provider.RemoveContext(ctx, session, "LinkedTransactions/"+linkedTransactionID, additionalHeaders)
 	if err != nil {
 		return nil, err
 	}
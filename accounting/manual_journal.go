@@ -1,12 +1,13 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
+	"fmt"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -20,13 +21,13 @@ type ManualJournal struct {
 	JournalLines []ManualJournalLine `json:"JournalLines" xml:"JournalLines>JournalLine"`
 
 	// Date journal was posted – YYYY-MM-DD
-	Date string `json:"Date,omitempty" xml:"Date,omitempty"`
+	Date Date `json:"Date,omitempty" xml:"Date,omitempty"`
 
 	// NoTax by default if you don’t specify this element. See Line Amount Types
-	LineAmountTypes string `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
 
 	// See Manual Journal Status Codes
-	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
+	Status ManualJournalStatus `json:"Status,omitempty" xml:"Status,omitempty"`
 
 	// Url link to a source document – shown as “Go to [appName]” in the Xero app
 	URL string `json:"Url,omitempty" xml:"Url,omitempty"`
@@ -38,7 +39,7 @@ type ManualJournal struct {
 	HasAttachments bool `json:"HasAttachments,omitempty" xml:"-"`
 
 	// Last modified date UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"-"`
 
 	// The Xero identifier for a Manual Journal
 	ManualJournalID string `json:"ManualJournalID,omitempty" xml:"ManualJournalID,omitempty"`
@@ -49,24 +50,6 @@ type ManualJournals struct {
 	ManualJournals []ManualJournal `json:"ManualJournals,omitempty" xml:"ManualJournal,omitempty"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (m *ManualJournals) convertDates() error {
-	var err error
-	for n := len(m.ManualJournals) - 1; n >= 0; n-- {
-		m.ManualJournals[n].Date, err = helpers.DotNetJSONTimeToRFC3339(m.ManualJournals[n].Date, false)
-		if err != nil {
-			return err
-		}
-		m.ManualJournals[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(m.ManualJournals[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalManualJournal(manualJournalResponseBytes []byte) (*ManualJournals, error) {
 	var manualJournalResponse *ManualJournals
 	err := json.Unmarshal(manualJournalResponseBytes, &manualJournalResponse)
@@ -74,27 +57,68 @@ func unmarshalManualJournal(manualJournalResponseBytes []byte) (*ManualJournals,
 		return nil, err
 	}
 
-	err = manualJournalResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return manualJournalResponse, err
 }
 
+//Validate checks the manual journals for problems Xero would reject them for, without sending them.
+//The journal lines must balance, i.e. their LineAmounts must add up to zero.
+//Fields that are only needed to create a manual journal are checked when it has no ManualJournalID.
+//Create and Update call it before making a request
+func (m *ManualJournals) Validate() error {
+	v := &validator{}
+	for n, manualJournal := range m.ManualJournals {
+		v.check(field("ManualJournals", n, "Status"), manualJournal.Status.Validate())
+		v.check(field("ManualJournals", n, "LineAmountTypes"), manualJournal.LineAmountTypes.Validate())
+
+		var total Decimal
+		var totalErr error
+		for l, journalLine := range manualJournal.JournalLines {
+			lineField := fmt.Sprintf("%s[%d]", field("ManualJournals", n, "JournalLines"), l)
+			v.required(lineField+".AccountCode", journalLine.AccountCode != "")
+			v.tracking(lineField+".Tracking", journalLine.Tracking)
+			if totalErr == nil {
+				total, totalErr = total.CheckedAdd(journalLine.LineAmount)
+			}
+		}
+		if totalErr != nil {
+			v.add(field("ManualJournals", n, "JournalLines"), "the LineAmounts are too large to add up")
+		} else if !total.IsZero() {
+			v.add(field("ManualJournals", n, "JournalLines"), "debits and credits must balance but the LineAmounts add up to %s", total)
+		}
+
+		if manualJournal.ManualJournalID == "" {
+			v.required(field("ManualJournals", n, "Narration"), manualJournal.Narration != "")
+			if len(manualJournal.JournalLines) < 2 {
+				v.add(field("ManualJournals", n, "JournalLines"), "at least 2 journal lines are required")
+			}
+		}
+	}
+	return v.err()
+}
+
 //Create will create manualJournals given an ManualJournals struct
 func (m *ManualJournals) Create(provider *xerogolang.Provider, session goth.Session) (*ManualJournals, error) {
+	return m.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (m *ManualJournals) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*ManualJournals, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := m.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body, err := xml.MarshalIndent(m, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	manualJournalResponseBytes, err := provider.Create(session, "ManualJournals", additionalHeaders, body)
+	manualJournalResponseBytes, err := provider.CreateContext(ctx, session, "ManualJournals", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -105,17 +129,27 @@ func (m *ManualJournals) Create(provider *xerogolang.Provider, session goth.Sess
 //Update will update an manualJournal given an ManualJournals struct
 //This will only handle single manualJournal - you cannot update multiple manualJournals in a single call
 func (m *ManualJournals) Update(provider *xerogolang.Provider, session goth.Session) (*ManualJournals, error) {
+	return m.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (m *ManualJournals) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*ManualJournals, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := m.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body, err := xml.MarshalIndent(m, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	manualJournalResponseBytes, err := provider.Update(session, "ManualJournals/"+m.ManualJournals[0].ManualJournalID, additionalHeaders, body)
+	manualJournalResponseBytes, err := provider.UpdateContext(ctx, session, "ManualJournals/"+m.ManualJournals[0].ManualJournalID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -128,6 +162,11 @@ func (m *ManualJournals) Update(provider *xerogolang.Provider, session goth.Sess
 //If you need details then then add a 'page' querystringParameter and get 100 ManualJournals at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindManualJournalsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*ManualJournals, error) {
+	return FindManualJournalsModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindManualJournalsModifiedSinceContext is like FindManualJournalsModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindManualJournalsModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*ManualJournals, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -136,7 +175,7 @@ func FindManualJournalsModifiedSince(provider *xerogolang.Provider, session goth
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	manualJournalResponseBytes, err := provider.Find(session, "ManualJournals", additionalHeaders, querystringParameters)
+	manualJournalResponseBytes, err := provider.FindContext(ctx, session, "ManualJournals", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -148,16 +187,36 @@ func FindManualJournalsModifiedSince(provider *xerogolang.Provider, session goth
 //If you need details then then add a 'page' querystringParameter and get 100 ManualJournals at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindManualJournals(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*ManualJournals, error) {
-	return FindManualJournalsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindManualJournalsContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindManualJournalsContext is like FindManualJournals but uses ctx for the request so that it can be cancelled or given a deadline
+func FindManualJournalsContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*ManualJournals, error) {
+	return FindManualJournalsModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//IterateManualJournals returns an Iterator over every manual journal matching querystringParameters
+func IterateManualJournals(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) *Iterator[ManualJournal] {
+	return iterate(ctx, provider, session, querystringParameters, FindManualJournalsContext, func(manualJournals *ManualJournals) []ManualJournal { return manualJournals.ManualJournals })
+}
+
+//ForEachManualJournal calls fn with every manual journal matching querystringParameters, stopping at the first error fn returns
+func ForEachManualJournal(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string, fn func(manualJournal ManualJournal) error) error {
+	return ForEach(IterateManualJournals(ctx, provider, session, querystringParameters), fn)
 }
 
 //FindManualJournal will get a single manualJournal - manualJournalID can be a GUID for an manualJournal or an manualJournal number
 func FindManualJournal(provider *xerogolang.Provider, session goth.Session, manualJournalID string) (*ManualJournals, error) {
+	return FindManualJournalContext(context.Background(), provider, session, manualJournalID)
+}
+
+//FindManualJournalContext is like FindManualJournal but uses ctx for the request so that it can be cancelled or given a deadline
+func FindManualJournalContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, manualJournalID string) (*ManualJournals, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	manualJournalResponseBytes, err := provider.Find(session, "ManualJournals/"+manualJournalID, additionalHeaders, nil)
+	manualJournalResponseBytes, err := provider.FindContext(ctx, session, "ManualJournals/"+manualJournalID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -169,21 +228,21 @@ func FindManualJournal(provider *xerogolang.Provider, session goth.Session, manu
 func GenerateExampleManualJournal() *ManualJournals {
 	lineItem := ManualJournalLine{
 		Description: "Importing & Exporting Services",
-		LineAmount:  395.00,
+		LineAmount:  MustParseDecimal("395.00"),
 		AccountCode: "200",
 	}
 
 	lineItem2 := ManualJournalLine{
 		Description: "Importing & Exporting Services",
-		LineAmount:  -395.00,
+		LineAmount:  MustParseDecimal("-395.00"),
 		AccountCode: "310",
 	}
 
 	manualJournal := ManualJournal{
 		Narration:       "Missed Importing & Exporting Invoice",
-		Date:            helpers.TodayRFC3339(),
-		LineAmountTypes: "Exclusive",
-		Status:          "DRAFT",
+		Date:            Today(),
+		LineAmountTypes: LineAmountExclusive,
+		Status:          ManualJournalStatusDraft,
 		JournalLines:    []ManualJournalLine{},
 	}
 
@@ -1,10 +1,10 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -66,13 +66,13 @@ type Organisation struct {
 	DefaultPurchasesTax string `json:"DefaultPurchasesTax,omitempty"`
 
 	// Shown if set. See lock dates
-	PeriodLockDate string `json:"PeriodLockDate,omitempty"`
+	PeriodLockDate Date `json:"PeriodLockDate,omitempty"`
 
 	// Shown if set. See lock dates
-	EndOfYearLockDate string `json:"EndOfYearLockDate,omitempty"`
+	EndOfYearLockDate Date `json:"EndOfYearLockDate,omitempty"`
 
 	// Timestamp when the organisation was created in Xero
-	CreatedDateUTC string `json:"CreatedDateUTC,omitempty"`
+	CreatedDateUTC DateTime `json:"CreatedDateUTC,omitempty"`
 
 	// Timezone specifications
 	Timezone string `json:"Timezone,omitempty"`
@@ -106,11 +106,16 @@ type OrganisationCollection struct {
 
 //FindOrganisation returns details about the Xero organisation you're connected to
 func FindOrganisation(provider *xerogolang.Provider, session goth.Session) (*OrganisationCollection, error) {
+	return FindOrganisationContext(context.Background(), provider, session)
+}
+
+//FindOrganisationContext is like FindOrganisation but uses ctx for the request so that it can be cancelled or given a deadline
+func FindOrganisationContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*OrganisationCollection, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	organisationResponseBytes, err := provider.Find(session, "Organisation", additionalHeaders, nil)
+	organisationResponseBytes, err := provider.FindContext(ctx, session, "Organisation", additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -121,14 +126,5 @@ func FindOrganisation(provider *xerogolang.Provider, session goth.Session) (*Org
 		return nil, err
 	}
 
-	organisationResponse.Organisations[0].PeriodLockDate, err = helpers.DotNetJSONTimeToRFC3339(organisationResponse.Organisations[0].PeriodLockDate, false)
-	if err != nil {
-		return nil, err
-	}
-	organisationResponse.Organisations[0].CreatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(organisationResponse.Organisations[0].CreatedDateUTC, true)
-	if err != nil {
-		return nil, err
-	}
-
 	return organisationResponse, nil
 }
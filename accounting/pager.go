@@ -0,0 +1,165 @@
+package accounting
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/markbates/goth"
+)
+
+//pageSize is the number of records Xero returns in a full page. A shorter page is the last one
+const pageSize = 100
+
+//pageResult is a page of records or the error that stopped it being fetched
+type pageResult[T any] struct {
+	records []T
+	err     error
+}
+
+//pager walks a paged endpoint one record at a time, fetching each page only when it is needed.
+//If prefetch is set the following page is fetched in the background while the current one is used
+type pager[T any] struct {
+	ctx                   context.Context
+	fetch                 func(ctx context.Context, querystringParameters map[string]string) ([]T, error)
+	querystringParameters map[string]string
+	page                  int
+	prefetch              bool
+	pending               chan pageResult[T]
+	records               []T
+	index                 int
+	current               T
+	done                  bool
+	err                   error
+}
+
+//newPager starts at the page given in querystringParameters, or the first page if there isn't one
+func newPager[T any](ctx context.Context, querystringParameters map[string]string, fetch func(ctx context.Context, querystringParameters map[string]string) ([]T, error)) *pager[T] {
+	page := 1
+	if requestedPage, err := strconv.Atoi(querystringParameters["page"]); err == nil && requestedPage > 0 {
+		page = requestedPage
+	}
+	return &pager[T]{
+		ctx:                   ctx,
+		fetch:                 fetch,
+		querystringParameters: querystringParameters,
+		page:                  page,
+	}
+}
+
+//next moves to the next record, fetching another page if the current one has been used up
+func (p *pager[T]) next() bool {
+	for p.index >= len(p.records) {
+		if p.done || p.err != nil {
+			return false
+		}
+		if err := p.ctx.Err(); err != nil {
+			p.err = err
+			return false
+		}
+
+		result := p.nextPage()
+		if result.err != nil {
+			p.err = result.err
+			return false
+		}
+		p.records, p.index = result.records, 0
+
+		if len(result.records) < pageSize {
+			p.done = true
+		} else if p.prefetch {
+			p.startFetch()
+		}
+	}
+
+	p.current = p.records[p.index]
+	p.index++
+	return true
+}
+
+//nextPage returns the page being prefetched, or fetches the next page if there isn't one
+func (p *pager[T]) nextPage() pageResult[T] {
+	if p.pending != nil {
+		result := <-p.pending
+		p.pending = nil
+		return result
+	}
+	records, err := p.fetch(p.ctx, p.nextQuerystringParameters())
+	return pageResult[T]{records: records, err: err}
+}
+
+//startFetch fetches the next page in the background. The channel is buffered so the
+//goroutine finishes even if the caller stops iterating
+func (p *pager[T]) startFetch() {
+	querystringParameters := p.nextQuerystringParameters()
+	pending := make(chan pageResult[T], 1)
+	go func() {
+		records, err := p.fetch(p.ctx, querystringParameters)
+		pending <- pageResult[T]{records: records, err: err}
+	}()
+	p.pending = pending
+}
+
+//nextQuerystringParameters copies the caller's parameters with the next page number added
+func (p *pager[T]) nextQuerystringParameters() map[string]string {
+	querystringParameters := make(map[string]string, len(p.querystringParameters)+1)
+	for key, value := range p.querystringParameters {
+		querystringParameters[key] = value
+	}
+	querystringParameters["page"] = strconv.Itoa(p.page)
+	p.page++
+	return querystringParameters
+}
+
+//Iterator walks every record of a paged endpoint matching a query, fetching a page of 100 at a time as it is needed.
+//No requests are made until Next is called and iteration stops when the context it was created with is cancelled.
+//Iterators are created by IterateInvoices, IterateContacts and the other Iterate functions
+type Iterator[T any] struct {
+	pager *pager[T]
+}
+
+//iterate returns an Iterator over the records picked out by records from each page returned by find
+func iterate[C any, T any](ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string, find func(context.Context, *xerogolang.Provider, goth.Session, map[string]string) (*C, error), records func(*C) []T) *Iterator[T] {
+	return &Iterator[T]{
+		pager: newPager(ctx, querystringParameters, func(ctx context.Context, querystringParameters map[string]string) ([]T, error) {
+			page, err := find(ctx, provider, session, querystringParameters)
+			if err != nil {
+				return nil, err
+			}
+			return records(page), nil
+		}),
+	}
+}
+
+//Prefetch makes the iterator fetch the next page in the background while the current page is used.
+//It must be called before the first call to Next
+func (i *Iterator[T]) Prefetch() *Iterator[T] {
+	i.pager.prefetch = true
+	return i
+}
+
+//Next moves to the next record, returning false when there are no more or an error occurred
+func (i *Iterator[T]) Next() bool {
+	return i.pager.next()
+}
+
+//Value returns the current record
+func (i *Iterator[T]) Value() T {
+	return i.pager.current
+}
+
+//Err returns the error that stopped the iteration, if any
+func (i *Iterator[T]) Err() error {
+	return i.pager.err
+}
+
+//ForEach calls fn with every record of iterator, stopping at the first error fn returns
+func ForEach[T any](iterator *Iterator[T], fn func(record T) error) error {
+	for iterator.Next() {
+		err := fn(iterator.Value())
+		if err != nil {
+			return err
+		}
+	}
+	return iterator.Err()
+}
@@ -0,0 +1,135 @@
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//fakePages serves total records, pageSize at a time, recording the pages requested
+type fakePages struct {
+	mutex sync.Mutex
+	total int
+	pages []string
+}
+
+func (f *fakePages) fetch(ctx context.Context, querystringParameters map[string]string) ([]int, error) {
+	f.mutex.Lock()
+	f.pages = append(f.pages, querystringParameters["page"])
+	f.mutex.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	page, _ := strconv.Atoi(querystringParameters["page"])
+	var records []int
+	for record := (page - 1) * pageSize; record < page*pageSize && record < f.total; record++ {
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func Test_Pager(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	for _, prefetch := range []bool{false, true} {
+		pages := &fakePages{total: 250}
+		querystringParameters := map[string]string{"where": `Status=="PAID"`}
+		pager := newPager(context.Background(), querystringParameters, pages.fetch)
+		pager.prefetch = prefetch
+
+		count := 0
+		for pager.next() {
+			a.Equal(count, pager.current)
+			count++
+		}
+		a.NoError(pager.err)
+		a.Equal(250, count)
+		a.Equal([]string{"1", "2", "3"}, pages.pages, "a short page is the last one")
+		a.Equal(map[string]string{"where": `Status=="PAID"`}, querystringParameters)
+	}
+
+	pages := &fakePages{total: 200}
+	pager := newPager(context.Background(), nil, pages.fetch)
+	count := 0
+	for pager.next() {
+		count++
+	}
+	a.Equal(200, count)
+	a.Equal([]string{"1", "2", "3"}, pages.pages, "an empty page is the last one")
+}
+
+func Test_Pager_StartPage(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	pages := &fakePages{total: 150}
+	pager := newPager(context.Background(), map[string]string{"page": "2"}, pages.fetch)
+	a.True(pager.next())
+	a.Equal(100, pager.current)
+}
+
+func Test_Pager_Errors(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pages := &fakePages{total: 1000}
+	pager := newPager(ctx, nil, pages.fetch)
+	a.True(pager.next())
+	cancel()
+	for pager.next() {
+	}
+	a.Equal(context.Canceled, pager.err)
+	a.Equal([]string{"1"}, pages.pages)
+
+	failure := errors.New("xero: 500 An error occurred")
+	pager = newPager(context.Background(), nil, func(ctx context.Context, querystringParameters map[string]string) ([]int, error) {
+		return nil, failure
+	})
+	a.False(pager.next())
+	a.False(pager.next())
+	a.Equal(failure, pager.err)
+}
+
+func Test_ForEachInvoice(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		invoices := Invoices{Invoices: []Invoice{}}
+		for number := (page - 1) * pageSize; number < page*pageSize && number < 150; number++ {
+			invoices.Invoices = append(invoices.Invoices, Invoice{InvoiceNumber: strconv.Itoa(number)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(invoices)
+	}))
+	defer ts.Close()
+	provider, session := testProvider(t, ts)
+
+	var numbers []string
+	err := ForEachInvoice(context.Background(), provider, session, nil, func(invoice Invoice) error {
+		numbers = append(numbers, invoice.InvoiceNumber)
+		return nil
+	})
+	a.NoError(err)
+	a.Len(numbers, 150)
+	a.Equal("149", numbers[149])
+
+	failure := errors.New("stop")
+	invoices := IterateInvoices(context.Background(), provider, session, nil).Prefetch()
+	err = ForEach(invoices, func(invoice Invoice) error {
+		return failure
+	})
+	a.Equal(failure, err)
+	a.Equal("0", invoices.Value().InvoiceNumber)
+}
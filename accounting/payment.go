@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -23,13 +23,13 @@ type Payment struct {
 	Account *Account `json:"Account,omitempty" xml:"Account,omitempty"`
 
 	// Date the payment is being made (YYYY-MM-DD) e.g. 2009-09-06
-	Date string `json:"Date,omitempty" xml:"Date,omitempty"`
+	Date Date `json:"Date,omitempty" xml:"Date,omitempty"`
 
 	// Exchange rate when payment is received. Only used for non base currency invoices and credit notes e.g. 0.7500
-	CurrencyRate float64 `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
+	CurrencyRate Decimal `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
 
 	// The amount of the payment. Must be less than or equal to the outstanding amount owing on the invoice e.g. 200.00
-	Amount float64 `json:"Amount,omitempty" xml:"Amount,omitempty"`
+	Amount Decimal `json:"Amount,omitempty" xml:"Amount,omitempty"`
 
 	// An optional description for the payment e.g. Direct Debit
 	Reference string `json:"Reference,omitempty" xml:"Reference,omitempty"`
@@ -38,13 +38,13 @@ type Payment struct {
 	IsReconciled bool `json:"IsReconciled,omitempty" xml:"IsReconciled,omitempty"`
 
 	// The status of the payment.
-	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
+	Status PaymentStatus `json:"Status,omitempty" xml:"Status,omitempty"`
 
 	// See Payment Types.
-	PaymentType string `json:"PaymentType,omitempty" xml:"-"`
+	PaymentType PaymentType `json:"PaymentType,omitempty" xml:"-"`
 
 	// UTC timestamp of last update to the payment
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"-"`
 
 	// The Xero identifier for an Payment e.g. 297c2dc5-cc47-4afd-8ec8-74990b8761e9
 	PaymentID string `json:"PaymentID,omitempty" xml:"PaymentID,omitempty"`
@@ -55,24 +55,6 @@ type Payments struct {
 	Payments []Payment `json:"Payments" xml:"Payment"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (p *Payments) convertDates() error {
-	var err error
-	for n := len(p.Payments) - 1; n >= 0; n-- {
-		p.Payments[n].Date, err = helpers.DotNetJSONTimeToRFC3339(p.Payments[n].Date, false)
-		if err != nil {
-			return err
-		}
-		p.Payments[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(p.Payments[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalPayment(paymentResponseBytes []byte) (*Payments, error) {
 	var paymentResponse *Payments
 	err := json.Unmarshal(paymentResponseBytes, &paymentResponse)
@@ -80,27 +62,56 @@ func unmarshalPayment(paymentResponseBytes []byte) (*Payments, error) {
 		return nil, err
 	}
 
-	err = paymentResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return paymentResponse, err
 }
 
+//Validate checks the payments for problems Xero would reject them for, without sending them.
+//Fields that are only needed to create a payment are checked when it has no PaymentID.
+//Create and Update call it before making a request
+func (p *Payments) Validate() error {
+	v := &validator{}
+	for n, payment := range p.Payments {
+		v.check(field("Payments", n, "Status"), payment.Status.Validate())
+
+		if payment.PaymentID == "" {
+			hasInvoice := payment.Invoice != nil && (payment.Invoice.InvoiceID != "" || payment.Invoice.InvoiceNumber != "")
+			hasCreditNote := payment.CreditNote != nil && (payment.CreditNote.CreditNoteID != "" || payment.CreditNote.CreditNoteNumber != "")
+			if !hasInvoice && !hasCreditNote {
+				v.add(field("Payments", n, "Invoice"), "an Invoice or CreditNote is required")
+			}
+			v.required(field("Payments", n, "Account"), payment.Account != nil && (payment.Account.AccountID != "" || payment.Account.Code != ""))
+			v.required(field("Payments", n, "Date"), !payment.Date.IsZero())
+			if payment.Amount.Sign() <= 0 {
+				v.add(field("Payments", n, "Amount"), "must be more than zero")
+			}
+		}
+	}
+	return v.err()
+}
+
 //Create will create payments given an Payments struct
 func (p *Payments) Create(provider *xerogolang.Provider, session goth.Session) (*Payments, error) {
+	return p.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (p *Payments) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Payments, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body, err := xml.MarshalIndent(p, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	paymentResponseBytes, err := provider.Create(session, "Payments", additionalHeaders, body)
+	paymentResponseBytes, err := provider.CreateContext(ctx, session, "Payments", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -112,11 +123,21 @@ func (p *Payments) Create(provider *xerogolang.Provider, session goth.Session) (
 //This will only handle single payment - you cannot update multiple payments in a single call
 //Payments cannot be modified, only created and deleted.
 func (p *Payments) Update(provider *xerogolang.Provider, session goth.Session) (*Payments, error) {
+	return p.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (p *Payments) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Payments, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	//we can only update the status on a payment so we must strip out all the other values in order to update it
 	paymentToMarshal := Payment{
 		Status: p.Payments[0].Status,
@@ -127,7 +148,7 @@ func (p *Payments) Update(provider *xerogolang.Provider, session goth.Session) (
 		return nil, err
 	}
 
-	paymentResponseBytes, err := provider.Update(session, "Payments/"+p.Payments[0].PaymentID, additionalHeaders, body)
+	paymentResponseBytes, err := provider.UpdateContext(ctx, session, "Payments/"+p.Payments[0].PaymentID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +159,11 @@ func (p *Payments) Update(provider *xerogolang.Provider, session goth.Session) (
 //FindPaymentsModifiedSince will get all payments modified after a specified date.
 //additional querystringParameters such as where, page, order can be added as a map
 func FindPaymentsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Payments, error) {
+	return FindPaymentsModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindPaymentsModifiedSinceContext is like FindPaymentsModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindPaymentsModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Payments, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -146,7 +172,7 @@ func FindPaymentsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	paymentResponseBytes, err := provider.Find(session, "Payments", additionalHeaders, querystringParameters)
+	paymentResponseBytes, err := provider.FindContext(ctx, session, "Payments", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -156,16 +182,36 @@ func FindPaymentsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 
 //FindPayments will get all payments.
 func FindPayments(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Payments, error) {
-	return FindPaymentsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindPaymentsContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindPaymentsContext is like FindPayments but uses ctx for the request so that it can be cancelled or given a deadline
+func FindPaymentsContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Payments, error) {
+	return FindPaymentsModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//IteratePayments returns an Iterator over every payment matching querystringParameters
+func IteratePayments(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) *Iterator[Payment] {
+	return iterate(ctx, provider, session, querystringParameters, FindPaymentsContext, func(payments *Payments) []Payment { return payments.Payments })
+}
+
+//ForEachPayment calls fn with every payment matching querystringParameters, stopping at the first error fn returns
+func ForEachPayment(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string, fn func(payment Payment) error) error {
+	return ForEach(IteratePayments(ctx, provider, session, querystringParameters), fn)
 }
 
 //FindPayment will get a single payment - paymentID must be a GUID for an payment
 func FindPayment(provider *xerogolang.Provider, session goth.Session, paymentID string) (*Payments, error) {
+	return FindPaymentContext(context.Background(), provider, session, paymentID)
+}
+
+//FindPaymentContext is like FindPayment but uses ctx for the request so that it can be cancelled or given a deadline
+func FindPaymentContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, paymentID string) (*Payments, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	paymentResponseBytes, err := provider.Find(session, "Payments/"+paymentID, additionalHeaders, nil)
+	paymentResponseBytes, err := provider.FindContext(ctx, session, "Payments/"+paymentID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -175,11 +221,16 @@ func FindPayment(provider *xerogolang.Provider, session goth.Session, paymentID
 
 //RemovePayment will get a single payment - paymentID must be a GUID for an payment
 func RemovePayment(provider *xerogolang.Provider, session goth.Session, paymentID string) (*Payments, error) {
+	return RemovePaymentContext(context.Background(), provider, session, paymentID)
+}
+
+//RemovePaymentContext is like RemovePayment but uses ctx for the request so that it can be cancelled or given a deadline
+func RemovePaymentContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, paymentID string) (*Payments, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	paymentResponseBytes, err := provider.Remove(session, "Payments/"+paymentID, additionalHeaders)
+	paymentResponseBytes, err := provider.RemoveContext(ctx, session, "Payments/"+paymentID, additionalHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -188,9 +239,9 @@ func RemovePayment(provider *xerogolang.Provider, session goth.Session, paymentI
 }
 
 //GenerateExamplePayment Creates an Example payment
-func GenerateExamplePayment(invoiceID string, amount float64) *Payments {
+func GenerateExamplePayment(invoiceID string, amount Decimal) *Payments {
 	payment := Payment{
-		Date:   helpers.TodayRFC3339(),
+		Date:   Today(),
 		Amount: amount,
 		Invoice: &Invoice{
 			InvoiceID: invoiceID,
@@ -0,0 +1,56 @@
+package accounting
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FindPDF(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path+" "+r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", "application/pdf")
+		io.WriteString(w, "%PDF-1.4")
+	}))
+	defer ts.Close()
+	provider, session := testProvider(t, ts)
+
+	for _, find := range []func() (io.ReadCloser, error){
+		func() (io.ReadCloser, error) { return FindInvoicePDF(provider, session, "INV-001") },
+		func() (io.ReadCloser, error) { return FindCreditNotePDF(provider, session, "credit-note-id") },
+		func() (io.ReadCloser, error) { return FindPurchaseOrderPDF(provider, session, "PO-001") },
+	} {
+		pdf, err := find()
+		a.NoError(err)
+		data, err := io.ReadAll(pdf)
+		a.NoError(err)
+		a.NoError(pdf.Close())
+		a.Equal("%PDF-1.4", string(data))
+	}
+
+	a.Equal([]string{
+		"/api.xro/2.0/Invoices/INV-001 application/pdf",
+		"/api.xro/2.0/CreditNotes/credit-note-id application/pdf",
+		"/api.xro/2.0/PurchaseOrders/PO-001 application/pdf",
+	}, requests)
+}
+
+func Test_FindPDF_NotFound(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+	provider, session := testProvider(t, ts)
+
+	pdf, err := FindInvoicePDF(provider, session, "missing")
+	a.Nil(pdf)
+	a.Error(err)
+}
@@ -1,12 +1,13 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
+	"io"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -20,13 +21,13 @@ type PurchaseOrder struct {
 	Contact Contact `json:"Contact" xml:"Contact"`
 
 	// Date purchase order was issued – YYYY-MM-DD. If the Date element is not specified then it will default to the current date based on the timezone setting of the organisation
-	Date string `json:"DateString,omitempty" xml:"Date,omitempty"`
+	Date Date `json:"DateString,omitempty" xml:"Date,omitempty"`
 
 	// Date the goods are to be delivered – YYYY-MM-DD
-	DeliveryDate string `json:"DeliveryDateString,omitempty" xml:"DeliveryDate,omitempty"`
+	DeliveryDate Date `json:"DeliveryDateString,omitempty" xml:"DeliveryDate,omitempty"`
 
 	// Line amounts are exclusive of tax by default if you don’t specify this element. See Line Amount Types
-	LineAmountTypes string `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
 
 	// Unique alpha numeric code identifying purchase order (when missing will auto-generate from your Organisation Invoice Settings)
 	PurchaseOrderNumber string `json:"PurchaseOrderNumber,omitempty" xml:"PurchaseOrderNumber,omitempty"`
@@ -41,7 +42,7 @@ type PurchaseOrder struct {
 	CurrencyCode string `json:"CurrencyCode,omitempty" xml:"CurrencyCode,omitempty"`
 
 	// See Purchase Order Status Codes
-	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
+	Status PurchaseOrderStatus `json:"Status,omitempty" xml:"Status,omitempty"`
 
 	// Boolean to set whether the purchase order should be marked as “sent”. This can be set only on purchase orders that have been approved or billed
 	SentToContact bool `json:"SentToContact,omitempty" xml:"SentToContact,omitempty"`
@@ -59,31 +60,31 @@ type PurchaseOrder struct {
 	DeliveryInstructions string `json:"DeliveryInstructions,omitempty" xml:"DeliveryInstructions,omitempty"`
 
 	// The date the goods are expected to arrive.
-	ExpectedArrivalDate string `json:"ExpectedArrivalDate,omitempty" xml:"ExpectedArrivalDate,omitempty"`
+	ExpectedArrivalDate Date `json:"ExpectedArrivalDate,omitempty" xml:"ExpectedArrivalDate,omitempty"`
 
 	// Xero generated unique identifier for purchase order
 	PurchaseOrderID string `json:"PurchaseOrderID,omitempty" xml:"PurchaseOrderID,omitempty"`
 
 	// The currency rate for a multicurrency purchase order. As no rate can be specified, the XE.com day rate is used.
-	CurrencyRate float64 `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
+	CurrencyRate Decimal `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
 
 	// Total of purchase order excluding taxes
-	SubTotal float64 `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
+	SubTotal Decimal `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
 
 	// Total tax on purchase order
-	TotalTax float64 `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
+	TotalTax Decimal `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
 
 	// Total of Purchase Order tax inclusive (i.e. SubTotal + TotalTax)
-	Total float64 `json:"Total,omitempty" xml:"Total,omitempty"`
+	Total Decimal `json:"Total,omitempty" xml:"Total,omitempty"`
 
 	// Total of discounts applied on the purchase order line items
-	TotalDiscount float64 `json:"TotalDiscount,omitempty" xml:"TotalDiscount,omitempty"`
+	TotalDiscount Decimal `json:"TotalDiscount,omitempty" xml:"TotalDiscount,omitempty"`
 
 	// boolean to indicate if a purchase order has an attachment
 	HasAttachments bool `json:"HasAttachments,omitempty" xml:"-"`
 
 	// Last modified date UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"-"`
 }
 
 //PurchaseOrders contains a collection of PurchaseOrders
@@ -91,20 +92,6 @@ type PurchaseOrders struct {
 	PurchaseOrders []PurchaseOrder `json:"PurchaseOrders" xml:"PurchaseOrder"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (p *PurchaseOrders) convertDates() error {
-	var err error
-	for n := len(p.PurchaseOrders) - 1; n >= 0; n-- {
-		p.PurchaseOrders[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(p.PurchaseOrders[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalPurchaseOrder(purchaseOrderResponseBytes []byte) (*PurchaseOrders, error) {
 	var purchaseOrderResponse *PurchaseOrders
 	err := json.Unmarshal(purchaseOrderResponseBytes, &purchaseOrderResponse)
@@ -112,27 +99,51 @@ func unmarshalPurchaseOrder(purchaseOrderResponseBytes []byte) (*PurchaseOrders,
 		return nil, err
 	}
 
-	err = purchaseOrderResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return purchaseOrderResponse, err
 }
 
+//Validate checks the purchase orders for problems Xero would reject them for, without sending them.
+//Fields that are only needed to create a purchase order are checked when it has no PurchaseOrderID.
+//Create and Update call it before making a request
+func (p *PurchaseOrders) Validate() error {
+	v := &validator{}
+	for n, purchaseOrder := range p.PurchaseOrders {
+		v.check(field("PurchaseOrders", n, "Status"), purchaseOrder.Status.Validate())
+		v.check(field("PurchaseOrders", n, "LineAmountTypes"), purchaseOrder.LineAmountTypes.Validate())
+		v.maxLength(field("PurchaseOrders", n, "DeliveryInstructions"), purchaseOrder.DeliveryInstructions, 500)
+		v.lineItems(field("PurchaseOrders", n, "LineItems"), purchaseOrder.LineItems, false)
+
+		if purchaseOrder.PurchaseOrderID == "" {
+			v.contact(field("PurchaseOrders", n, "Contact"), purchaseOrder.Contact)
+			v.required(field("PurchaseOrders", n, "LineItems"), len(purchaseOrder.LineItems) > 0)
+		}
+	}
+	return v.err()
+}
+
 //Create will create purchaseOrders given an PurchaseOrders struct
 func (p *PurchaseOrders) Create(provider *xerogolang.Provider, session goth.Session) (*PurchaseOrders, error) {
+	return p.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (p *PurchaseOrders) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*PurchaseOrders, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body, err := xml.MarshalIndent(p, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	purchaseOrderResponseBytes, err := provider.Create(session, "PurchaseOrders", additionalHeaders, body)
+	purchaseOrderResponseBytes, err := provider.CreateContext(ctx, session, "PurchaseOrders", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -143,17 +154,27 @@ func (p *PurchaseOrders) Create(provider *xerogolang.Provider, session goth.Sess
 //Update will update an purchaseOrder given an PurchaseOrders struct
 //This will only handle single purchaseOrder - you cannot update multiple purchaseOrders in a single call
 func (p *PurchaseOrders) Update(provider *xerogolang.Provider, session goth.Session) (*PurchaseOrders, error) {
+	return p.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (p *PurchaseOrders) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*PurchaseOrders, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
 	}
 
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	body, err := xml.MarshalIndent(p, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	purchaseOrderResponseBytes, err := provider.Update(session, "PurchaseOrders/"+p.PurchaseOrders[0].PurchaseOrderID, additionalHeaders, body)
+	purchaseOrderResponseBytes, err := provider.UpdateContext(ctx, session, "PurchaseOrders/"+p.PurchaseOrders[0].PurchaseOrderID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -165,6 +186,11 @@ func (p *PurchaseOrders) Update(provider *xerogolang.Provider, session goth.Sess
 //Paging is enforced by default. 100 purchase orders are returned per page.
 //additional querystringParameters such as page, order, status, DateFrom & DateTo can be added as a map
 func FindPurchaseOrdersModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*PurchaseOrders, error) {
+	return FindPurchaseOrdersModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindPurchaseOrdersModifiedSinceContext is like FindPurchaseOrdersModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindPurchaseOrdersModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*PurchaseOrders, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -173,7 +199,7 @@ func FindPurchaseOrdersModifiedSince(provider *xerogolang.Provider, session goth
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	purchaseOrderResponseBytes, err := provider.Find(session, "PurchaseOrders", additionalHeaders, querystringParameters)
+	purchaseOrderResponseBytes, err := provider.FindContext(ctx, session, "PurchaseOrders", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -184,16 +210,36 @@ func FindPurchaseOrdersModifiedSince(provider *xerogolang.Provider, session goth
 //FindPurchaseOrders will get all PurchaseOrders. Paging is enforced by default. 100 purchase orders are returned per page.
 //additional querystringParameters such as page, order, status, DateFrom & DateTo can be added as a map
 func FindPurchaseOrders(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*PurchaseOrders, error) {
-	return FindPurchaseOrdersModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindPurchaseOrdersContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindPurchaseOrdersContext is like FindPurchaseOrders but uses ctx for the request so that it can be cancelled or given a deadline
+func FindPurchaseOrdersContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*PurchaseOrders, error) {
+	return FindPurchaseOrdersModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//IteratePurchaseOrders returns an Iterator over every purchase order matching querystringParameters
+func IteratePurchaseOrders(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) *Iterator[PurchaseOrder] {
+	return iterate(ctx, provider, session, querystringParameters, FindPurchaseOrdersContext, func(purchaseOrders *PurchaseOrders) []PurchaseOrder { return purchaseOrders.PurchaseOrders })
+}
+
+//ForEachPurchaseOrder calls fn with every purchase order matching querystringParameters, stopping at the first error fn returns
+func ForEachPurchaseOrder(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string, fn func(purchaseOrder PurchaseOrder) error) error {
+	return ForEach(IteratePurchaseOrders(ctx, provider, session, querystringParameters), fn)
 }
 
 //FindPurchaseOrder will get a single purchaseOrder - purchaseOrderID can be a GUID for an purchaseOrder or an purchaseOrder number
 func FindPurchaseOrder(provider *xerogolang.Provider, session goth.Session, purchaseOrderID string) (*PurchaseOrders, error) {
+	return FindPurchaseOrderContext(context.Background(), provider, session, purchaseOrderID)
+}
+
+//FindPurchaseOrderContext is like FindPurchaseOrder but uses ctx for the request so that it can be cancelled or given a deadline
+func FindPurchaseOrderContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, purchaseOrderID string) (*PurchaseOrders, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	purchaseOrderResponseBytes, err := provider.Find(session, "PurchaseOrders/"+purchaseOrderID, additionalHeaders, nil)
+	purchaseOrderResponseBytes, err := provider.FindContext(ctx, session, "PurchaseOrders/"+purchaseOrderID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -201,12 +247,27 @@ func FindPurchaseOrder(provider *xerogolang.Provider, session goth.Session, purc
 	return unmarshalPurchaseOrder(purchaseOrderResponseBytes)
 }
 
+//FindPurchaseOrderPDF gets the PDF of a single purchase order as Xero renders it - purchaseOrderID can be a GUID for a purchase order or a purchase order number.
+//The PDF is streamed rather than read into memory and the caller must close the returned reader
+func FindPurchaseOrderPDF(provider *xerogolang.Provider, session goth.Session, purchaseOrderID string) (io.ReadCloser, error) {
+	return FindPurchaseOrderPDFContext(context.Background(), provider, session, purchaseOrderID)
+}
+
+//FindPurchaseOrderPDFContext is like FindPurchaseOrderPDF but uses ctx for the request so that it can be cancelled or given a deadline
+func FindPurchaseOrderPDFContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, purchaseOrderID string) (io.ReadCloser, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/pdf",
+	}
+
+	return provider.FindStreamContext(ctx, session, "PurchaseOrders/"+purchaseOrderID, additionalHeaders, nil)
+}
+
 //GenerateExamplePurchaseOrder Creates an Example purchaseOrder
 func GenerateExamplePurchaseOrder(contactID string) *PurchaseOrders {
 	lineItem := LineItem{
 		Description: "Importing & Exporting Services",
-		Quantity:    1.00,
-		UnitAmount:  395.00,
+		Quantity:    MustParseDecimal("1.00"),
+		UnitAmount:  MustParseDecimal("395.00"),
 		AccountCode: "200",
 	}
 
@@ -214,8 +275,8 @@ func GenerateExamplePurchaseOrder(contactID string) *PurchaseOrders {
 		Contact: Contact{
 			ContactID: contactID,
 		},
-		Date:            helpers.TodayRFC3339(),
-		LineAmountTypes: "Exclusive",
+		Date:            Today(),
+		LineAmountTypes: LineAmountExclusive,
 		LineItems:       []LineItem{},
 	}
 
@@ -0,0 +1,368 @@
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/markbates/goth"
+)
+
+//Quote is an offer to a customer of goods or services at a price, which can be turned into an invoice once it is accepted
+type Quote struct {
+
+	// See Contacts
+	Contact Contact `json:"Contact" xml:"Contact"`
+
+	// See LineItems
+	LineItems []LineItem `json:"LineItems" xml:"LineItems>LineItem"`
+
+	// Date quote was issued – YYYY-MM-DD
+	Date Date `json:"DateString,omitempty" xml:"Date,omitempty"`
+
+	// Date the quote expires – YYYY-MM-DD
+	ExpiryDate Date `json:"ExpiryDateString,omitempty" xml:"ExpiryDate,omitempty"`
+
+	// Line amounts are exclusive of tax by default if you don’t specify this element. See Line Amount Types
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+
+	// Unique alpha numeric code identifying quote (when missing will auto-generate from your Organisation Invoice Settings) (max length = 255)
+	QuoteNumber string `json:"QuoteNumber,omitempty" xml:"QuoteNumber,omitempty"`
+
+	// Additional reference number (max length = 4000)
+	Reference string `json:"Reference,omitempty" xml:"Reference,omitempty"`
+
+	// See BrandingThemes
+	BrandingThemeID string `json:"BrandingThemeID,omitempty" xml:"BrandingThemeID,omitempty"`
+
+	// The title of the quote (max length = 100)
+	Title string `json:"Title,omitempty" xml:"Title,omitempty"`
+
+	// The summary of the quote (max length = 3000)
+	Summary string `json:"Summary,omitempty" xml:"Summary,omitempty"`
+
+	// The terms of the quote (max length = 4000)
+	Terms string `json:"Terms,omitempty" xml:"Terms,omitempty"`
+
+	// The currency that quote has been raised in (see Currencies)
+	CurrencyCode string `json:"CurrencyCode,omitempty" xml:"CurrencyCode,omitempty"`
+
+	// The currency rate for a multicurrency quote
+	CurrencyRate Decimal `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
+
+	// See Quote Status Codes
+	Status QuoteStatus `json:"Status,omitempty" xml:"Status,omitempty"`
+
+	// Total of quote excluding taxes
+	SubTotal Decimal `json:"SubTotal,omitempty" xml:"-"`
+
+	// Total tax on quote
+	TotalTax Decimal `json:"TotalTax,omitempty" xml:"-"`
+
+	// Total of Quote tax inclusive (i.e. SubTotal + TotalTax)
+	Total Decimal `json:"Total,omitempty" xml:"-"`
+
+	// Total of discounts applied on the quote line items
+	TotalDiscount Decimal `json:"TotalDiscount,omitempty" xml:"-"`
+
+	// Xero generated unique identifier for quote
+	QuoteID string `json:"QuoteID,omitempty" xml:"QuoteID,omitempty"`
+
+	// Last modified date UTC format
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"-"`
+}
+
+//Quotes contains a collection of Quotes
+type Quotes struct {
+	Quotes []Quote `json:"Quotes" xml:"Quote"`
+}
+
+//quoteTransitions lists the statuses a quote can be moved to from each status
+var quoteTransitions = map[QuoteStatus][]QuoteStatus{
+	QuoteStatusDraft:    {QuoteStatusSent, QuoteStatusDeleted},
+	QuoteStatusSent:     {QuoteStatusDraft, QuoteStatusAccepted, QuoteStatusDeclined, QuoteStatusDeleted},
+	QuoteStatusAccepted: {QuoteStatusSent, QuoteStatusInvoiced, QuoteStatusDeleted},
+	QuoteStatusDeclined: {QuoteStatusSent, QuoteStatusDeleted},
+	QuoteStatusInvoiced: {QuoteStatusAccepted},
+}
+
+//CanTransitionTo reports whether a quote with status q can be moved to status next.
+//A new quote with no status can be created as a draft or sent
+func (q QuoteStatus) CanTransitionTo(next QuoteStatus) bool {
+	if q == next {
+		return true
+	}
+	if q == "" {
+		return next == QuoteStatusDraft || next == QuoteStatusSent
+	}
+	for _, allowed := range quoteTransitions[q] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+//SetStatus moves the quote to status, returning an error if that isn't allowed from its current status.
+//Call Update on the Quotes containing it to save the change
+func (q *Quote) SetStatus(status QuoteStatus) error {
+	err := status.Validate()
+	if err != nil {
+		return err
+	}
+	if !q.Status.CanTransitionTo(status) {
+		return fmt.Errorf("A %s quote cannot be changed to %s", q.Status, status)
+	}
+	q.Status = status
+	return nil
+}
+
+//ToInvoices returns a draft ACCREC invoice for an accepted quote, with the quote's contact, line items and currency,
+//ready to be created with Invoices.Create. The quote number is used as the invoice's reference
+func (q *Quote) ToInvoices() (*Invoices, error) {
+	if q.Status != QuoteStatusAccepted {
+		return nil, fmt.Errorf("Only an ACCEPTED quote can be invoiced but quote %s is %s", q.QuoteNumber, q.Status)
+	}
+
+	lineItems := make([]LineItem, len(q.LineItems))
+	for n, lineItem := range q.LineItems {
+		lineItem.LineItemID = ""
+		lineItems[n] = lineItem
+	}
+
+	invoice := Invoice{
+		Type: InvoiceTypeAccRec,
+		Contact: Contact{
+			ContactID: q.Contact.ContactID,
+			Name:      q.Contact.Name,
+		},
+		LineItems:       lineItems,
+		Date:            Today(),
+		LineAmountTypes: q.LineAmountTypes,
+		Reference:       q.QuoteNumber,
+		BrandingThemeID: q.BrandingThemeID,
+		CurrencyCode:    q.CurrencyCode,
+		Status:          InvoiceStatusDraft,
+	}
+
+	return &Invoices{
+		Invoices: []Invoice{invoice},
+	}, nil
+}
+
+func unmarshalQuote(quoteResponseBytes []byte) (*Quotes, error) {
+	var quoteResponse *Quotes
+	err := json.Unmarshal(quoteResponseBytes, &quoteResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return quoteResponse, err
+}
+
+//Validate checks the quotes for problems Xero would reject them for, without sending them.
+//Xero needs the Contact and Date of every quote, including when it is updated.
+//Create and Update call it before making a request
+func (q *Quotes) Validate() error {
+	v := &validator{}
+	for n, quote := range q.Quotes {
+		v.check(field("Quotes", n, "Status"), quote.Status.Validate())
+		v.check(field("Quotes", n, "LineAmountTypes"), quote.LineAmountTypes.Validate())
+		v.contact(field("Quotes", n, "Contact"), quote.Contact)
+		v.required(field("Quotes", n, "Date"), !quote.Date.IsZero())
+		v.maxLength(field("Quotes", n, "QuoteNumber"), quote.QuoteNumber, 255)
+		v.maxLength(field("Quotes", n, "Reference"), quote.Reference, 4000)
+		v.maxLength(field("Quotes", n, "Title"), quote.Title, 100)
+		v.maxLength(field("Quotes", n, "Summary"), quote.Summary, 3000)
+		v.maxLength(field("Quotes", n, "Terms"), quote.Terms, 4000)
+		v.lineItems(field("Quotes", n, "LineItems"), quote.LineItems, true)
+
+		if quote.QuoteID == "" {
+			v.required(field("Quotes", n, "LineItems"), len(quote.LineItems) > 0)
+		}
+	}
+	return v.err()
+}
+
+//Create will create quotes given a Quotes struct
+func (q *Quotes) Create(provider *xerogolang.Provider, session goth.Session) (*Quotes, error) {
+	return q.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (q *Quotes) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Quotes, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	err := q.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := xml.MarshalIndent(q, "  ", "	")
+	if err != nil {
+		return nil, err
+	}
+
+	quoteResponseBytes, err := provider.CreateContext(ctx, session, "Quotes", additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalQuote(quoteResponseBytes)
+}
+
+//Update will update a quote given a Quotes struct
+//This will only handle single quote - you cannot update multiple quotes in a single call
+func (q *Quotes) Update(provider *xerogolang.Provider, session goth.Session) (*Quotes, error) {
+	return q.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (q *Quotes) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Quotes, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	err := q.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := xml.MarshalIndent(q, "  ", "	")
+	if err != nil {
+		return nil, err
+	}
+
+	quoteResponseBytes, err := provider.UpdateContext(ctx, session, "Quotes/"+q.Quotes[0].QuoteID, additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalQuote(quoteResponseBytes)
+}
+
+//FindQuotesModifiedSince will get all Quotes modified after a specified date.
+//Paging is enforced by default. 100 quotes are returned per page.
+//additional querystringParameters such as page, order, Status, ContactID, QuoteNumber, DateFrom, DateTo,
+//ExpiryDateFrom & ExpiryDateTo can be added as a map
+func FindQuotesModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Quotes, error) {
+	return FindQuotesModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindQuotesModifiedSinceContext is like FindQuotesModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindQuotesModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Quotes, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	if !modifiedSince.Equal(dayZero) {
+		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
+	}
+
+	quoteResponseBytes, err := provider.FindContext(ctx, session, "Quotes", additionalHeaders, querystringParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalQuote(quoteResponseBytes)
+}
+
+//FindQuotes will get all Quotes. Paging is enforced by default. 100 quotes are returned per page.
+//additional querystringParameters such as page, order, Status, ContactID, QuoteNumber, DateFrom, DateTo,
+//ExpiryDateFrom & ExpiryDateTo can be added as a map
+func FindQuotes(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Quotes, error) {
+	return FindQuotesContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindQuotesContext is like FindQuotes but uses ctx for the request so that it can be cancelled or given a deadline
+func FindQuotesContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Quotes, error) {
+	return FindQuotesModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//IterateQuotes returns an Iterator over every quote matching querystringParameters
+func IterateQuotes(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) *Iterator[Quote] {
+	return iterate(ctx, provider, session, querystringParameters, FindQuotesContext, func(quotes *Quotes) []Quote { return quotes.Quotes })
+}
+
+//ForEachQuote calls fn with every quote matching querystringParameters, stopping at the first error fn returns
+func ForEachQuote(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string, fn func(quote Quote) error) error {
+	return ForEach(IterateQuotes(ctx, provider, session, querystringParameters), fn)
+}
+
+//FindQuote will get a single quote - quoteID must be a GUID for a quote
+func FindQuote(provider *xerogolang.Provider, session goth.Session, quoteID string) (*Quotes, error) {
+	return FindQuoteContext(context.Background(), provider, session, quoteID)
+}
+
+//FindQuoteContext is like FindQuote but uses ctx for the request so that it can be cancelled or given a deadline
+func FindQuoteContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, quoteID string) (*Quotes, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	quoteResponseBytes, err := provider.FindContext(ctx, session, "Quotes/"+quoteID, additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalQuote(quoteResponseBytes)
+}
+
+//FindQuoteHistory gets the history and notes of a quote
+func FindQuoteHistory(provider *xerogolang.Provider, session goth.Session, quoteID string) (*HistoryRecords, error) {
+	return FindQuoteHistoryContext(context.Background(), provider, session, quoteID)
+}
+
+//FindQuoteHistoryContext is like FindQuoteHistory but uses ctx for the request so that it can be cancelled or given a deadline
+func FindQuoteHistoryContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, quoteID string) (*HistoryRecords, error) {
+	return FindHistoryAndNotesContext(ctx, provider, session, "Quotes", quoteID)
+}
+
+//CreateQuoteHistory adds notes to the history of a quote
+func CreateQuoteHistory(provider *xerogolang.Provider, session goth.Session, quoteID string, historyRecords *HistoryRecords) (*HistoryRecords, error) {
+	return CreateQuoteHistoryContext(context.Background(), provider, session, quoteID, historyRecords)
+}
+
+//CreateQuoteHistoryContext is like CreateQuoteHistory but uses ctx for the request so that it can be cancelled or given a deadline
+func CreateQuoteHistoryContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, quoteID string, historyRecords *HistoryRecords) (*HistoryRecords, error) {
+	return historyRecords.CreateContext(ctx, provider, session, "Quotes", quoteID)
+}
+
+//GenerateExampleQuote Creates an Example quote
+func GenerateExampleQuote(contactID string) *Quotes {
+	lineItem := LineItem{
+		Description: "Importing & Exporting Services",
+		Quantity:    MustParseDecimal("1.00"),
+		UnitAmount:  MustParseDecimal("395.00"),
+		AccountCode: "200",
+	}
+
+	quote := Quote{
+		Contact: Contact{
+			ContactID: contactID,
+		},
+		Date:            Today(),
+		ExpiryDate:      DateOf(time.Now().AddDate(0, 0, 30)),
+		LineAmountTypes: LineAmountExclusive,
+		Title:           "Importing & Exporting Services",
+		Status:          QuoteStatusDraft,
+		LineItems:       []LineItem{},
+	}
+
+	quote.LineItems = append(quote.LineItems, lineItem)
+
+	quoteCollection := &Quotes{
+		Quotes: []Quote{},
+	}
+
+	quoteCollection.Quotes = append(quoteCollection.Quotes, quote)
+
+	return quoteCollection
+}
@@ -0,0 +1,120 @@
+package accounting
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Quote_SetStatus(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	quote := GenerateExampleQuote("contact-id").Quotes[0]
+	a.Error(quote.SetStatus(QuoteStatusAccepted), "a draft must be sent before it is accepted")
+	a.Equal(QuoteStatusDraft, quote.Status)
+
+	a.NoError(quote.SetStatus(QuoteStatusSent))
+	a.NoError(quote.SetStatus(QuoteStatusAccepted))
+	a.NoError(quote.SetStatus(QuoteStatusInvoiced))
+	a.Error(quote.SetStatus(QuoteStatusDeleted), "an invoiced quote can't be deleted")
+	a.Error(quote.SetStatus("APPROVED"))
+	a.Equal(QuoteStatusInvoiced, quote.Status)
+}
+
+func Test_Quote_ToInvoices(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	quote := GenerateExampleQuote("contact-id").Quotes[0]
+	quote.QuoteNumber = "QU-0001"
+	quote.CurrencyCode = "NZD"
+	quote.LineItems[0].LineItemID = "line-item-id"
+
+	_, err := quote.ToInvoices()
+	a.Error(err, "a draft quote can't be invoiced")
+
+	quote.Status = QuoteStatusAccepted
+	invoices, err := quote.ToInvoices()
+	a.NoError(err)
+	a.NoError(invoices.Validate())
+
+	invoice := invoices.Invoices[0]
+	a.Equal(InvoiceTypeAccRec, invoice.Type)
+	a.Equal(InvoiceStatusDraft, invoice.Status)
+	a.Equal("contact-id", invoice.Contact.ContactID)
+	a.Equal("QU-0001", invoice.Reference)
+	a.Equal("NZD", invoice.CurrencyCode)
+	a.Equal("", invoice.LineItems[0].LineItemID)
+	a.True(MustParseDecimal("395").Equal(invoice.LineItems[0].UnitAmount))
+	a.Equal("line-item-id", quote.LineItems[0].LineItemID, "the quote's line items are left alone")
+}
+
+func Test_Quotes_Validate(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	a.NoError(GenerateExampleQuote("contact-id").Validate())
+
+	quotes := &Quotes{Quotes: []Quote{{QuoteID: "quote-id", Status: QuoteStatusSent}}}
+	a.Equal(ValidationErrors{
+		{Field: "Quotes[0].Contact", Message: "a ContactID or Name is required"},
+		{Field: "Quotes[0].Date", Message: "is required"},
+	}, quotes.Validate())
+}
+
+func Test_FindQuotes(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("If-Modified-Since"))
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"Quotes": [{"QuoteID": "quote-id", "QuoteNumber": "QU-0001", "Status": "ACCEPTED", "DateString": "2018-07-01T00:00:00", "Total": 460.00}]}`)
+	}))
+	defer ts.Close()
+	provider, session := testProvider(t, ts)
+
+	quotes, err := FindQuotes(provider, session, nil)
+	a.NoError(err)
+	a.Equal(QuoteStatusAccepted, quotes.Quotes[0].Status)
+	a.Equal("2018-07-01", quotes.Quotes[0].Date.String())
+	a.Equal("460", quotes.Quotes[0].Total.String())
+
+	_, err = FindQuote(provider, session, "quote-id")
+	a.NoError(err)
+
+	a.Equal([]string{
+		"GET /api.xro/2.0/Quotes ",
+		"GET /api.xro/2.0/Quotes/quote-id ",
+	}, requests)
+}
+
+func Test_Quotes_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.Equal("PUT", r.Method)
+		a.Equal("/api.xro/2.0/Quotes", r.URL.Path)
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Quotes{Quotes: []Quote{{QuoteID: "quote-id"}}})
+	}))
+	defer ts.Close()
+	provider, session := testProvider(t, ts)
+
+	quotes, err := GenerateExampleQuote("contact-id").Create(provider, session)
+	a.NoError(err)
+	a.Equal("quote-id", quotes.Quotes[0].QuoteID)
+	a.Contains(body, "<Quote>")
+	a.Contains(body, "<Status>DRAFT</Status>")
+	a.Contains(body, "<UnitAmount>395</UnitAmount>")
+}
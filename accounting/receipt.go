@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -19,7 +19,7 @@ type Receipt struct {
 	Contact Contact `json:"Contact" xml:"Contact"`
 
 	// Date of receipt – YYYY-MM-DD
-	Date string `json:"Date" xml:"Date"`
+	Date Date `json:"Date" xml:"Date"`
 
 	// See LineItems
 	LineItems []LineItem `json:"LineItems" xml:"LineItems>LineItem"`
@@ -28,16 +28,16 @@ type Receipt struct {
 	Reference string `json:"Reference,omitempty" xml:"Reference,omitempty"`
 
 	// See Line Amount Types
-	LineAmountTypes string `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
 
 	// Total of receipt excluding taxes
-	SubTotal float64 `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
+	SubTotal Decimal `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
 
 	// Total tax on receipt
-	TotalTax float64 `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
+	TotalTax Decimal `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
 
 	// Total of receipt tax inclusive (i.e. SubTotal + TotalTax)
-	Total float64 `json:"Total,omitempty" xml:"Total,omitempty"`
+	Total Decimal `json:"Total,omitempty" xml:"Total,omitempty"`
 
 	// Xero generated unique identifier for receipt
 	ReceiptID string `json:"ReceiptID,omitempty" xml:"ReceiptID,omitempty"`
@@ -49,7 +49,7 @@ type Receipt struct {
 	ReceiptNumber int `json:"ReceiptNumber,omitempty" xml:"ReceiptNumber,omitempty"`
 
 	// Last modified date UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"-"`
 
 	// boolean to indicate if a receipt has an attachment
 	HasAttachments bool `json:"HasAttachments,omitempty" xml:"HasAttachments,omitempty"`
@@ -63,24 +63,6 @@ type Receipts struct {
 	Receipts []Receipt `json:"Receipts" xml:"Receipt"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (r *Receipts) convertDates() error {
-	var err error
-	for n := len(r.Receipts) - 1; n >= 0; n-- {
-		r.Receipts[n].Date, err = helpers.DotNetJSONTimeToRFC3339(r.Receipts[n].Date, false)
-		if err != nil {
-			return err
-		}
-		r.Receipts[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(r.Receipts[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalReceipt(receiptResponseBytes []byte) (*Receipts, error) {
 	var receiptResponse *Receipts
 	err := json.Unmarshal(receiptResponseBytes, &receiptResponse)
@@ -88,16 +70,16 @@ func unmarshalReceipt(receiptResponseBytes []byte) (*Receipts, error) {
 		return nil, err
 	}
 
-	err = receiptResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return receiptResponse, err
 }
 
 //Create will create receipts given an Receipts struct
 func (r *Receipts) Create(provider *xerogolang.Provider, session goth.Session) (*Receipts, error) {
+	return r.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (r *Receipts) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Receipts, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -108,7 +90,7 @@ func (r *Receipts) Create(provider *xerogolang.Provider, session goth.Session) (
 		return nil, err
 	}
 
-	receiptResponseBytes, err := provider.Create(session, "Receipts", additionalHeaders, body)
+	receiptResponseBytes, err := provider.CreateContext(ctx, session, "Receipts", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +101,11 @@ func (r *Receipts) Create(provider *xerogolang.Provider, session goth.Session) (
 //Update will update an receipt given an Receipts struct
 //This will only handle single receipt - you cannot update multiple receipts in a single call
 func (r *Receipts) Update(provider *xerogolang.Provider, session goth.Session) (*Receipts, error) {
+	return r.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (r *Receipts) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Receipts, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -140,7 +127,7 @@ func (r *Receipts) Update(provider *xerogolang.Provider, session goth.Session) (
 		return nil, err
 	}
 
-	receiptResponseBytes, err := provider.Update(session, "Receipts/"+r.Receipts[0].ReceiptID, additionalHeaders, body)
+	receiptResponseBytes, err := provider.UpdateContext(ctx, session, "Receipts/"+r.Receipts[0].ReceiptID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -153,6 +140,11 @@ func (r *Receipts) Update(provider *xerogolang.Provider, session goth.Session) (
 //If you need details then add a 'page' querystringParameter and get 100 Receipts at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindReceiptsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Receipts, error) {
+	return FindReceiptsModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindReceiptsModifiedSinceContext is like FindReceiptsModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindReceiptsModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Receipts, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -161,7 +153,7 @@ func FindReceiptsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	receiptResponseBytes, err := provider.Find(session, "Receipts", additionalHeaders, querystringParameters)
+	receiptResponseBytes, err := provider.FindContext(ctx, session, "Receipts", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -173,16 +165,26 @@ func FindReceiptsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 //If you need details then add a 'page' querystringParameter and get 100 Receipts at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindReceipts(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Receipts, error) {
-	return FindReceiptsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindReceiptsContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindReceiptsContext is like FindReceipts but uses ctx for the request so that it can be cancelled or given a deadline
+func FindReceiptsContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Receipts, error) {
+	return FindReceiptsModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
 }
 
 //FindReceipt will get a single receipt - receiptID can be a GUID for an receipt or an receipt number
 func FindReceipt(provider *xerogolang.Provider, session goth.Session, receiptID string) (*Receipts, error) {
+	return FindReceiptContext(context.Background(), provider, session, receiptID)
+}
+
+//FindReceiptContext is like FindReceipt but uses ctx for the request so that it can be cancelled or given a deadline
+func FindReceiptContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, receiptID string) (*Receipts, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	receiptResponseBytes, err := provider.Find(session, "Receipts/"+receiptID, additionalHeaders, nil)
+	receiptResponseBytes, err := provider.FindContext(ctx, session, "Receipts/"+receiptID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -194,8 +196,8 @@ func FindReceipt(provider *xerogolang.Provider, session goth.Session, receiptID
 func GenerateExampleReceipt(userID string, contactID string) *Receipts {
 	lineItem := LineItem{
 		Description: "Lunch at the Dream Cafe",
-		Quantity:    1.00,
-		UnitAmount:  55.00,
+		Quantity:    MustParseDecimal("1.00"),
+		UnitAmount:  MustParseDecimal("55.00"),
 		AccountCode: "400",
 	}
 
@@ -206,8 +208,8 @@ func GenerateExampleReceipt(userID string, contactID string) *Receipts {
 		Contact: Contact{
 			ContactID: contactID,
 		},
-		Date:            helpers.TodayRFC3339(),
-		LineAmountTypes: "Inclusive",
+		Date:            Today(),
+		LineAmountTypes: LineAmountInclusive,
 		LineItems:       []LineItem{},
 	}
 
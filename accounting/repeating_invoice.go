@@ -1,10 +1,11 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
+	"encoding/xml"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -12,7 +13,7 @@ import (
 type RepeatingInvoice struct {
 
 	// See Invoice Types
-	Type string `json:"Type,omitempty" xml:"Type,omitempty"`
+	Type InvoiceType `json:"Type,omitempty" xml:"Type,omitempty"`
 
 	// See Contacts
 	Contact Contact `json:"Contact" xml:"Contact"`
@@ -21,7 +22,7 @@ type RepeatingInvoice struct {
 	LineItems []LineItem `json:"LineItems,omitempty" xml:"LineItems>LineItem,omitempty"`
 
 	// Line amounts are exclusive of tax by default if you don’t specify this element. See Line Amount Types
-	LineAmountTypes string `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
 
 	// ACCREC only – additional reference number
 	Reference string `json:"Reference,omitempty" xml:"Reference,omitempty"`
@@ -33,22 +34,22 @@ type RepeatingInvoice struct {
 	CurrencyCode string `json:"CurrencyCode,omitempty" xml:"CurrencyCode,omitempty"`
 
 	// One of the following : DRAFT or AUTHORISED – See Invoice Status Codes
-	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
+	Status RepeatingInvoiceStatus `json:"Status,omitempty" xml:"Status,omitempty"`
 
 	// Total of invoice excluding taxes
-	SubTotal float64 `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
+	SubTotal Decimal `json:"SubTotal,omitempty" xml:"-"`
 
 	// Total tax on invoice
-	TotalTax float64 `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
+	TotalTax Decimal `json:"TotalTax,omitempty" xml:"-"`
 
 	// Total of Invoice tax inclusive (i.e. SubTotal + TotalTax)
-	Total float64 `json:"Total,omitempty" xml:"Total,omitempty"`
+	Total Decimal `json:"Total,omitempty" xml:"-"`
 
 	// Xero generated unique identifier for repeating invoice template
 	RepeatingInvoiceID string `json:"RepeatingInvoiceID,omitempty" xml:"RepeatingInvoiceID,omitempty"`
 
 	// boolean to indicate if an invoice has an attachment
-	HasAttachments bool `json:"HasAttachments,omitempty" xml:"HasAttachments,omitempty"`
+	HasAttachments bool `json:"HasAttachments,omitempty" xml:"-"`
 
 	//Specifies when the repeating invoice will be created
 	Schedule Schedule `json:"Schedule,omitempty" xml:"Schedule,omitempty"`
@@ -59,53 +60,167 @@ type RepeatingInvoices struct {
 	RepeatingInvoices []RepeatingInvoice `json:"RepeatingInvoices,omitempty" xml:"RepeatingInvoice,omitempty"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (i *RepeatingInvoices) convertDates() error {
-	var err error
-	for n := len(i.RepeatingInvoices) - 1; n >= 0; n-- {
-		i.RepeatingInvoices[n].Schedule.EndDate, err = helpers.DotNetJSONTimeToRFC3339(i.RepeatingInvoices[n].Schedule.EndDate, false)
-		if err != nil {
-			return err
-		}
+func unmarshalRepeatingInvoices(repeatingInvoiceResponseBytes []byte) (*RepeatingInvoices, error) {
+	var repeatingInvoiceResponse *RepeatingInvoices
+	err := json.Unmarshal(repeatingInvoiceResponseBytes, &repeatingInvoiceResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return repeatingInvoiceResponse, err
+}
 
-		i.RepeatingInvoices[n].Schedule.StartDate, err = helpers.DotNetJSONTimeToRFC3339(i.RepeatingInvoices[n].Schedule.StartDate, false)
-		if err != nil {
-			return err
+//Validate checks the repeating invoices for problems Xero would reject them for, without sending them.
+//Fields that are only needed to create a template are checked when it has no RepeatingInvoiceID.
+//Create and Update call it before making a request
+func (r *RepeatingInvoices) Validate() error {
+	v := &validator{}
+	for n, repeatingInvoice := range r.RepeatingInvoices {
+		v.check(field("RepeatingInvoices", n, "Type"), repeatingInvoice.Type.Validate())
+		v.check(field("RepeatingInvoices", n, "Status"), repeatingInvoice.Status.Validate())
+		v.check(field("RepeatingInvoices", n, "LineAmountTypes"), repeatingInvoice.LineAmountTypes.Validate())
+		v.maxLength(field("RepeatingInvoices", n, "Reference"), repeatingInvoice.Reference, 255)
+		v.lineItems(field("RepeatingInvoices", n, "LineItems"), repeatingInvoice.LineItems, repeatingInvoice.Type == "" || repeatingInvoice.Type == InvoiceTypeAccRec)
+
+		schedule := repeatingInvoice.Schedule
+		scheduleSent := schedule != (Schedule{})
+		v.check(field("RepeatingInvoices", n, "Schedule.Unit"), schedule.Unit.Validate())
+		v.check(field("RepeatingInvoices", n, "Schedule.DueDateType"), schedule.DueDateType.Validate())
+		if scheduleSent && schedule.Period <= 0 {
+			v.add(field("RepeatingInvoices", n, "Schedule.Period"), "must be more than zero")
+		}
+		if !schedule.EndDate.IsZero() && !schedule.StartDate.IsZero() && schedule.EndDate.Before(schedule.StartDate) {
+			v.add(field("RepeatingInvoices", n, "Schedule.EndDate"), "must not be before the StartDate")
 		}
 
-		i.RepeatingInvoices[n].Schedule.NextScheduledDate, err = helpers.DotNetJSONTimeToRFC3339(i.RepeatingInvoices[n].Schedule.NextScheduledDate, false)
-		if err != nil {
-			return err
+		if repeatingInvoice.RepeatingInvoiceID == "" {
+			v.required(field("RepeatingInvoices", n, "Type"), repeatingInvoice.Type != "")
+			v.contact(field("RepeatingInvoices", n, "Contact"), repeatingInvoice.Contact)
+			v.required(field("RepeatingInvoices", n, "LineItems"), len(repeatingInvoice.LineItems) > 0)
+			//a Period left out of a schedule that is being sent has already been reported above
+			v.required(field("RepeatingInvoices", n, "Schedule.Period"), scheduleSent)
+			v.required(field("RepeatingInvoices", n, "Schedule.Unit"), schedule.Unit != "")
+			v.required(field("RepeatingInvoices", n, "Schedule.DueDateType"), schedule.DueDateType != "")
+			v.required(field("RepeatingInvoices", n, "Schedule.StartDate"), !schedule.StartDate.IsZero())
 		}
 	}
+	return v.err()
+}
 
-	return nil
+//Create will create repeating invoice templates given a RepeatingInvoices struct
+func (r *RepeatingInvoices) Create(provider *xerogolang.Provider, session goth.Session) (*RepeatingInvoices, error) {
+	return r.CreateContext(context.Background(), provider, session)
 }
 
-func unmarshalRepeatingInvoices(repeatingInvoiceResponseBytes []byte) (*RepeatingInvoices, error) {
-	var repeatingInvoiceResponse *RepeatingInvoices
-	err := json.Unmarshal(repeatingInvoiceResponseBytes, &repeatingInvoiceResponse)
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (r *RepeatingInvoices) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*RepeatingInvoices, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	err := r.Validate()
 	if err != nil {
 		return nil, err
 	}
 
-	err = repeatingInvoiceResponse.convertDates()
+	body, err := xml.MarshalIndent(r, "  ", "	")
 	if err != nil {
 		return nil, err
 	}
 
-	return repeatingInvoiceResponse, err
+	repeatingInvoiceResponseBytes, err := provider.CreateContext(ctx, session, "RepeatingInvoices", additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalRepeatingInvoices(repeatingInvoiceResponseBytes)
+}
+
+//Update will update a repeating invoice template given a RepeatingInvoices struct
+//This will only handle single repeating invoice - you cannot update multiple repeating invoices in a single call
+func (r *RepeatingInvoices) Update(provider *xerogolang.Provider, session goth.Session) (*RepeatingInvoices, error) {
+	return r.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (r *RepeatingInvoices) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*RepeatingInvoices, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	err := r.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := xml.MarshalIndent(r, "  ", "	")
+	if err != nil {
+		return nil, err
+	}
+
+	repeatingInvoiceResponseBytes, err := provider.UpdateContext(ctx, session, "RepeatingInvoices/"+r.RepeatingInvoices[0].RepeatingInvoiceID, additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalRepeatingInvoices(repeatingInvoiceResponseBytes)
+}
+
+//repeatingInvoiceDeletion is the body that deletes a repeating invoice template. Only the ID and status are sent
+//because Xero would validate a Contact or Schedule if they were included
+type repeatingInvoiceDeletion struct {
+	XMLName          xml.Name `xml:"RepeatingInvoices"`
+	RepeatingInvoice struct {
+		RepeatingInvoiceID string                 `xml:"RepeatingInvoiceID"`
+		Status             RepeatingInvoiceStatus `xml:"Status"`
+	} `xml:"RepeatingInvoice"`
+}
+
+//RemoveRepeatingInvoice will delete a repeating invoice template given its repeatingInvoiceID by setting its status to DELETED.
+//Invoices already created from the template are left alone
+func RemoveRepeatingInvoice(provider *xerogolang.Provider, session goth.Session, repeatingInvoiceID string) (*RepeatingInvoices, error) {
+	return RemoveRepeatingInvoiceContext(context.Background(), provider, session, repeatingInvoiceID)
+}
+
+//RemoveRepeatingInvoiceContext is like RemoveRepeatingInvoice but uses ctx for the request so that it can be cancelled or given a deadline
+func RemoveRepeatingInvoiceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, repeatingInvoiceID string) (*RepeatingInvoices, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	var deletion repeatingInvoiceDeletion
+	deletion.RepeatingInvoice.RepeatingInvoiceID = repeatingInvoiceID
+	deletion.RepeatingInvoice.Status = RepeatingInvoiceStatusDeleted
+
+	body, err := xml.MarshalIndent(deletion, "  ", "	")
+	if err != nil {
+		return nil, err
+	}
+
+	repeatingInvoiceResponseBytes, err := provider.UpdateContext(ctx, session, "RepeatingInvoices/"+repeatingInvoiceID, additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalRepeatingInvoices(repeatingInvoiceResponseBytes)
 }
 
 //FindRepeatingInvoices will get all repeatingInvoices
 //additional querystringParameters such as where and order can be added as a map
 func FindRepeatingInvoices(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*RepeatingInvoices, error) {
+	return FindRepeatingInvoicesContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindRepeatingInvoicesContext is like FindRepeatingInvoices but uses ctx for the request so that it can be cancelled or given a deadline
+func FindRepeatingInvoicesContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*RepeatingInvoices, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	repeatingInvoiceResponseBytes, err := provider.Find(session, "RepeatingInvoices", additionalHeaders, querystringParameters)
+	repeatingInvoiceResponseBytes, err := provider.FindContext(ctx, session, "RepeatingInvoices", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -115,14 +230,58 @@ func FindRepeatingInvoices(provider *xerogolang.Provider, session goth.Session,
 
 //FindRepeatingInvoice will get a single repeatingInvoice - RepeatingInvoiceID must be a GUID for a repeatingInvoice
 func FindRepeatingInvoice(provider *xerogolang.Provider, session goth.Session, repeatingInvoiceID string) (*RepeatingInvoices, error) {
+	return FindRepeatingInvoiceContext(context.Background(), provider, session, repeatingInvoiceID)
+}
+
+//FindRepeatingInvoiceContext is like FindRepeatingInvoice but uses ctx for the request so that it can be cancelled or given a deadline
+func FindRepeatingInvoiceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, repeatingInvoiceID string) (*RepeatingInvoices, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	repeatingInvoiceResponseBytes, err := provider.Find(session, "RepeatingInvoices/"+repeatingInvoiceID, additionalHeaders, nil)
+	repeatingInvoiceResponseBytes, err := provider.FindContext(ctx, session, "RepeatingInvoices/"+repeatingInvoiceID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	return unmarshalRepeatingInvoices(repeatingInvoiceResponseBytes)
 }
+
+//GenerateExampleRepeatingInvoice Creates an Example repeating invoice that is raised on the 1st of each month
+//and due on the 20th of the following month
+func GenerateExampleRepeatingInvoice(contactID string) *RepeatingInvoices {
+	lineItem := LineItem{
+		Description: "Monthly Importing & Exporting Services",
+		Quantity:    MustParseDecimal("1.00"),
+		UnitAmount:  MustParseDecimal("395.00"),
+		AccountCode: "200",
+	}
+
+	today := Today()
+	repeatingInvoice := RepeatingInvoice{
+		Type: InvoiceTypeAccRec,
+		Contact: Contact{
+			ContactID: contactID,
+		},
+		LineAmountTypes: LineAmountExclusive,
+		Status:          RepeatingInvoiceStatusDraft,
+		Schedule: Schedule{
+			Period:      1,
+			Unit:        ScheduleUnitMonthly,
+			DueDate:     20,
+			DueDateType: DueDateTypeOfFollowingMonth,
+			StartDate:   NewDate(today.Year(), today.Month()+1, 1),
+		},
+		LineItems: []LineItem{},
+	}
+
+	repeatingInvoice.LineItems = append(repeatingInvoice.LineItems, lineItem)
+
+	repeatingInvoiceCollection := &RepeatingInvoices{
+		RepeatingInvoices: []RepeatingInvoice{},
+	}
+
+	repeatingInvoiceCollection.RepeatingInvoices = append(repeatingInvoiceCollection.RepeatingInvoices, repeatingInvoice)
+
+	return repeatingInvoiceCollection
+}
@@ -0,0 +1,88 @@
+package accounting
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RepeatingInvoices_Validate(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	a.NoError(GenerateExampleRepeatingInvoice("contact-id").Validate())
+
+	repeatingInvoices := &RepeatingInvoices{RepeatingInvoices: []RepeatingInvoice{{
+		Type:      InvoiceTypeAccPay,
+		Contact:   Contact{ContactID: "contact-id"},
+		LineItems: []LineItem{{Description: "Rent"}},
+		Schedule: Schedule{
+			Period:    -1,
+			Unit:      "YEARLY",
+			StartDate: NewDate(2018, time.July, 1),
+			EndDate:   NewDate(2018, time.June, 1),
+		},
+	}}}
+	a.Equal(ValidationErrors{
+		{Field: "RepeatingInvoices[0].Schedule.Unit", Message: `"YEARLY" is not a valid schedule unit`},
+		{Field: "RepeatingInvoices[0].Schedule.Period", Message: "must be more than zero"},
+		{Field: "RepeatingInvoices[0].Schedule.EndDate", Message: "must not be before the StartDate"},
+		{Field: "RepeatingInvoices[0].Schedule.DueDateType", Message: "is required"},
+	}, repeatingInvoices.Validate())
+
+	repeatingInvoices.RepeatingInvoices[0].Schedule = Schedule{Unit: ScheduleUnitMonthly, DueDateType: DueDateTypeOfFollowingMonth, StartDate: NewDate(2018, time.July, 1)}
+	a.Equal(ValidationErrors{
+		{Field: "RepeatingInvoices[0].Schedule.Period", Message: "must be more than zero"},
+	}, repeatingInvoices.Validate())
+
+	repeatingInvoices.RepeatingInvoices[0].RepeatingInvoiceID = "template-id"
+	a.Equal(ValidationErrors{
+		{Field: "RepeatingInvoices[0].Schedule.Period", Message: "must be more than zero"},
+	}, repeatingInvoices.Validate())
+
+	repeatingInvoices.RepeatingInvoices[0].Schedule = Schedule{}
+	a.NoError(repeatingInvoices.Validate(), "an update can leave the schedule out")
+}
+
+func Test_RepeatingInvoices_CreateUpdateRemove(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"RepeatingInvoices": [{"RepeatingInvoiceID": "template-id", "Type": "ACCREC", "Schedule": {"Period": 1, "Unit": "MONTHLY", "DueDate": 20, "DueDateType": "OFFOLLOWINGMONTH", "StartDate": "/Date(1530403200000+0000)/"}}]}`)
+	}))
+	defer ts.Close()
+	provider, session := testProvider(t, ts)
+
+	repeatingInvoices, err := GenerateExampleRepeatingInvoice("contact-id").Create(provider, session)
+	a.NoError(err)
+	schedule := repeatingInvoices.RepeatingInvoices[0].Schedule
+	a.Equal(DueDateTypeOfFollowingMonth, schedule.DueDateType)
+	a.Equal(20, schedule.DueDate)
+	a.Equal(NewDate(2018, time.July, 1), schedule.StartDate)
+
+	repeatingInvoices.RepeatingInvoices[0].Schedule.Unit = ScheduleUnitWeekly
+	_, err = repeatingInvoices.Update(provider, session)
+	a.NoError(err)
+
+	_, err = RemoveRepeatingInvoice(provider, session, "template-id")
+	a.NoError(err)
+
+	a.Len(requests, 3)
+	a.Contains(requests[0], "PUT /api.xro/2.0/RepeatingInvoices ")
+	a.Contains(requests[0], "<DueDateType>OFFOLLOWINGMONTH</DueDateType>")
+	a.Contains(requests[0], "<Unit>MONTHLY</Unit>")
+	a.Contains(requests[1], "POST /api.xro/2.0/RepeatingInvoices/template-id ")
+	a.Contains(requests[1], "<Unit>WEEKLY</Unit>")
+	a.Contains(requests[2], "POST /api.xro/2.0/RepeatingInvoices/template-id ")
+	a.Contains(requests[2], "<Status>DELETED</Status>")
+	a.NotContains(requests[2], "Contact")
+}
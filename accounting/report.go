@@ -1,11 +1,11 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -22,7 +22,7 @@ type Report struct {
 	//The date of the report
 	ReportDate string `json:"ReportDate,omitempty" xml:"ReportDate,omitempty"`
 	// Last modified date UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"UpdatedDateUTC,omitempty"`
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"UpdatedDateUTC,omitempty"`
 	//Attributes of the report
 	Attributes *[]ReportAttribute `json:"Attributes,omitempty" xml:"Attributes>Attribute,omitempty"`
 	//Rows on the report that may contain cells, Attributes, or other rows
@@ -34,22 +34,6 @@ type Reports struct {
 	Reports []Report `json:"Reports" xml:"Report"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (r *Reports) convertDates() error {
-	var err error
-	for n := len(r.Reports) - 1; n >= 0; n-- {
-		if r.Reports[n].UpdatedDateUTC != "" {
-			r.Reports[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(r.Reports[n].UpdatedDateUTC, true)
-		}
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalReport(reportResponseBytes []byte) (*Reports, error) {
 	var reportResponse *Reports
 	err := json.Unmarshal(reportResponseBytes, &reportResponse)
@@ -57,17 +41,17 @@ func unmarshalReport(reportResponseBytes []byte) (*Reports, error) {
 		return nil, err
 	}
 
-	err = reportResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return reportResponse, err
 }
 
 //Run1099 will run the 1099 Report and marshal the results to a Report Struct
 //This Report will only work for US based Organisations
 func Run1099(provider *xerogolang.Provider, session goth.Session, reportYear int) (*Reports, error) {
+	return Run1099Context(context.Background(), provider, session, reportYear)
+}
+
+//Run1099Context is like Run1099 but uses ctx for the request so that it can be cancelled or given a deadline
+func Run1099Context(ctx context.Context, provider *xerogolang.Provider, session goth.Session, reportYear int) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -76,7 +60,7 @@ func Run1099(provider *xerogolang.Provider, session goth.Session, reportYear int
 		"reportYear": strconv.Itoa(reportYear),
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/TenNinetyNine", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindContext(ctx, session, "Reports/TenNinetyNine", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +71,11 @@ func Run1099(provider *xerogolang.Provider, session goth.Session, reportYear int
 //RunAgedPayablesByContact will run the Aged Payables By Contact Report and marshal the results to a Report Struct
 //Date, FromDate and ToDate can be added as optional paramters as a map
 func RunAgedPayablesByContact(provider *xerogolang.Provider, session goth.Session, contactID string, querystringParameters map[string]string) (*Reports, error) {
+	return RunAgedPayablesByContactContext(context.Background(), provider, session, contactID, querystringParameters)
+}
+
+//RunAgedPayablesByContactContext is like RunAgedPayablesByContact but uses ctx for the request so that it can be cancelled or given a deadline
+func RunAgedPayablesByContactContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, contactID string, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -99,7 +88,7 @@ func RunAgedPayablesByContact(provider *xerogolang.Provider, session goth.Sessio
 		}
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/AgedPayablesByContact", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindContext(ctx, session, "Reports/AgedPayablesByContact", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +99,11 @@ func RunAgedPayablesByContact(provider *xerogolang.Provider, session goth.Sessio
 //RunAgedReceivablesByContact will run the Aged Receivables By Contact Report and marshal the results to a Report Struct
 //Date, FromDate and ToDate can be added as optional paramters as a map
 func RunAgedReceivablesByContact(provider *xerogolang.Provider, session goth.Session, contactID string, querystringParameters map[string]string) (*Reports, error) {
+	return RunAgedReceivablesByContactContext(context.Background(), provider, session, contactID, querystringParameters)
+}
+
+//RunAgedReceivablesByContactContext is like RunAgedReceivablesByContact but uses ctx for the request so that it can be cancelled or given a deadline
+func RunAgedReceivablesByContactContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, contactID string, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -122,7 +116,7 @@ func RunAgedReceivablesByContact(provider *xerogolang.Provider, session goth.Ses
 		}
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/AgedReceivablesByContact", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindContext(ctx, session, "Reports/AgedReceivablesByContact", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -133,11 +127,16 @@ func RunAgedReceivablesByContact(provider *xerogolang.Provider, session goth.Ses
 //RunBalanceSheet will run the Balance Sheet Report and marshal the results to a Report Struct
 //date, trackingOptionID1, trackingOptionID2, standardLayout, and paymentsOnly can be added as optional paramters as a map
 func RunBalanceSheet(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
+	return RunBalanceSheetContext(context.Background(), provider, session, querystringParameters)
+}
+
+//RunBalanceSheetContext is like RunBalanceSheet but uses ctx for the request so that it can be cancelled or given a deadline
+func RunBalanceSheetContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/BalanceSheet", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindContext(ctx, session, "Reports/BalanceSheet", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -148,6 +147,11 @@ func RunBalanceSheet(provider *xerogolang.Provider, session goth.Session, querys
 //RunBankStatement will run the Bank Statement Report and marshal the results to a Report Struct
 //FromDate and ToDate can be added as optional paramters as a map
 func RunBankStatement(provider *xerogolang.Provider, session goth.Session, bankAccountID string, querystringParameters map[string]string) (*Reports, error) {
+	return RunBankStatementContext(context.Background(), provider, session, bankAccountID, querystringParameters)
+}
+
+//RunBankStatementContext is like RunBankStatement but uses ctx for the request so that it can be cancelled or given a deadline
+func RunBankStatementContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, bankAccountID string, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -160,7 +164,7 @@ func RunBankStatement(provider *xerogolang.Provider, session goth.Session, bankA
 		}
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/BankStatement", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindContext(ctx, session, "Reports/BankStatement", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -171,11 +175,16 @@ func RunBankStatement(provider *xerogolang.Provider, session goth.Session, bankA
 //RunBankSummary will run the Bank Summary Report and marshal the results to a Report Struct
 //FromDate and ToDate can be added as optional paramters as a map
 func RunBankSummary(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
+	return RunBankSummaryContext(context.Background(), provider, session, querystringParameters)
+}
+
+//RunBankSummaryContext is like RunBankSummary but uses ctx for the request so that it can be cancelled or given a deadline
+func RunBankSummaryContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/BankSummary", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindContext(ctx, session, "Reports/BankSummary", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -186,11 +195,16 @@ func RunBankSummary(provider *xerogolang.Provider, session goth.Session, queryst
 //RunBASReport will retrieve an individual BAS Report given a reportID and marshal the results to a Report Struct
 //Will only work for AU based Organisations
 func RunBASReport(provider *xerogolang.Provider, session goth.Session, reportID string) (*Reports, error) {
+	return RunBASReportContext(context.Background(), provider, session, reportID)
+}
+
+//RunBASReportContext is like RunBASReport but uses ctx for the request so that it can be cancelled or given a deadline
+func RunBASReportContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, reportID string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/"+reportID, additionalHeaders, nil)
+	reportResponseBytes, err := provider.FindContext(ctx, session, "Reports/"+reportID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -201,16 +215,26 @@ func RunBASReport(provider *xerogolang.Provider, session goth.Session, reportID
 //RunBASReports will retrieve all BAS Reports and marshal the results to a Report Struct
 //Will only work for AU based Organisations
 func RunBASReports(provider *xerogolang.Provider, session goth.Session) (*Reports, error) {
-	return RunBASReport(provider, session, "")
+	return RunBASReportsContext(context.Background(), provider, session)
+}
+
+//RunBASReportsContext is like RunBASReports but uses ctx for the request so that it can be cancelled or given a deadline
+func RunBASReportsContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Reports, error) {
+	return RunBASReportContext(ctx, provider, session, "")
 }
 
 //RunBudgetSummary will run the Budget Summary Report and marshal the results to a Report Struct
 func RunBudgetSummary(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
+	return RunBudgetSummaryContext(context.Background(), provider, session, querystringParameters)
+}
+
+//RunBudgetSummaryContext is like RunBudgetSummary but uses ctx for the request so that it can be cancelled or given a deadline
+func RunBudgetSummaryContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/BudgetSummary", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindContext(ctx, session, "Reports/BudgetSummary", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -221,11 +245,16 @@ func RunBudgetSummary(provider *xerogolang.Provider, session goth.Session, query
 //RunExecutiveSummary will run the Executive Summary Report and marshal the results to a Report Struct
 //date can be added as an optional paramter as a map
 func RunExecutiveSummary(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
+	return RunExecutiveSummaryContext(context.Background(), provider, session, querystringParameters)
+}
+
+//RunExecutiveSummaryContext is like RunExecutiveSummary but uses ctx for the request so that it can be cancelled or given a deadline
+func RunExecutiveSummaryContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/ExecutiveSummary", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindContext(ctx, session, "Reports/ExecutiveSummary", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -236,11 +265,16 @@ func RunExecutiveSummary(provider *xerogolang.Provider, session goth.Session, qu
 //RunGSTReport will retrieve an individual GST Report given a reportID and marshal the results to a Report Struct
 //Will only work for NZ based Organisations
 func RunGSTReport(provider *xerogolang.Provider, session goth.Session, reportID string) (*Reports, error) {
+	return RunGSTReportContext(context.Background(), provider, session, reportID)
+}
+
+//RunGSTReportContext is like RunGSTReport but uses ctx for the request so that it can be cancelled or given a deadline
+func RunGSTReportContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, reportID string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/"+reportID, additionalHeaders, nil)
+	reportResponseBytes, err := provider.FindContext(ctx, session, "Reports/"+reportID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -251,18 +285,28 @@ func RunGSTReport(provider *xerogolang.Provider, session goth.Session, reportID
 //RunGSTReports will retrieve all GST Reports and marshal the results to a Report Struct
 //Will only work for NZ based Organisations
 func RunGSTReports(provider *xerogolang.Provider, session goth.Session) (*Reports, error) {
-	return RunGSTReport(provider, session, "")
+	return RunGSTReportsContext(context.Background(), provider, session)
+}
+
+//RunGSTReportsContext is like RunGSTReports but uses ctx for the request so that it can be cancelled or given a deadline
+func RunGSTReportsContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Reports, error) {
+	return RunGSTReportContext(ctx, provider, session, "")
 }
 
 //RunProfitAndLoss will run the Profit And Loss Report and marshal the results to a Report Struct
 //date, trackingCategoryID, trackingOptionID, trackingCategoryID2, trackingOptionID2,
 //standardLayout, and paymentsOnly can be added as optional paramters as a map
 func RunProfitAndLoss(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
+	return RunProfitAndLossContext(context.Background(), provider, session, querystringParameters)
+}
+
+//RunProfitAndLossContext is like RunProfitAndLoss but uses ctx for the request so that it can be cancelled or given a deadline
+func RunProfitAndLossContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/ProfitAndLoss", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindContext(ctx, session, "Reports/ProfitAndLoss", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -273,11 +317,16 @@ func RunProfitAndLoss(provider *xerogolang.Provider, session goth.Session, query
 //RunTrialBalance will run the TrialBalance Report and marshal the results to a Report Struct
 //date and paymentsOnly can be added as optional paramters as a map
 func RunTrialBalance(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
+	return RunTrialBalanceContext(context.Background(), provider, session, querystringParameters)
+}
+
+//RunTrialBalanceContext is like RunTrialBalance but uses ctx for the request so that it can be cancelled or given a deadline
+func RunTrialBalanceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/TrialBalance", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindContext(ctx, session, "Reports/TrialBalance", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -4,20 +4,23 @@ package accounting
 type Schedule struct {
 
 	// Integer used with the unit e.g. 1 (every 1 week), 2 (every 2 months)
-	Period float64 `json:"Period,omitempty" xml:"Period,omitempty"`
+	Period int `json:"Period,omitempty" xml:"Period,omitempty"`
 
 	// One of the following : WEEKLY or MONTHLY
-	Unit string `json:"Unit,omitempty" xml:"Unit,omitempty"`
+	Unit ScheduleUnit `json:"Unit,omitempty" xml:"Unit,omitempty"`
 
 	// Integer used with due date type e.g 20 (of following month), 31 (of current month)
-	DueDate float64 `json:"DueDate,omitempty" xml:"DueDate,omitempty"`
+	DueDate int `json:"DueDate,omitempty" xml:"DueDate,omitempty"`
+
+	// One of the following : DAYSAFTERBILLDATE, DAYSAFTERBILLMONTH, OFCURRENTMONTH or OFFOLLOWINGMONTH
+	DueDateType DueDateType `json:"DueDateType,omitempty" xml:"DueDateType,omitempty"`
 
 	// Date the first invoice of the current version of the repeating schedule was generated (changes when repeating invoice is edited)
-	StartDate string `json:"StartDate,omitempty" xml:"StartDate,omitempty"`
+	StartDate Date `json:"StartDate,omitempty" xml:"StartDate,omitempty"`
 
 	// The calendar date of the next invoice in the schedule to be generated
-	NextScheduledDate string `json:"NextScheduledDate,omitempty" xml:"NextScheduledDate,omitempty"`
+	NextScheduledDate Date `json:"NextScheduledDate,omitempty" xml:"-"`
 
 	// Invoice end date – only returned if the template has an end date set
-	EndDate string `json:"EndDate,omitempty" xml:"EndDate,omitempty"`
+	EndDate Date `json:"EndDate,omitempty" xml:"EndDate,omitempty"`
 }
@@ -1,6 +1,7 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 
@@ -41,6 +42,11 @@ func unmarshalTrackingCategory(trackingCategoryResponseBytes []byte) (*TrackingC
 
 //Create will create trackingCategories given an TrackingCategories struct
 func (t *TrackingCategories) Create(provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
+	return t.CreateContext(context.Background(), provider, session)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (t *TrackingCategories) CreateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -51,7 +57,7 @@ func (t *TrackingCategories) Create(provider *xerogolang.Provider, session goth.
 		return nil, err
 	}
 
-	trackingCategoryResponseBytes, err := provider.Create(session, "TrackingCategories", additionalHeaders, body)
+	trackingCategoryResponseBytes, err := provider.CreateContext(ctx, session, "TrackingCategories", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -62,6 +68,11 @@ func (t *TrackingCategories) Create(provider *xerogolang.Provider, session goth.
 //Update will update an trackingCategory given an TrackingCategories struct
 //This will only handle single trackingCategory - you cannot update multiple trackingCategories in a single call
 func (t *TrackingCategories) Update(provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
+	return t.UpdateContext(context.Background(), provider, session)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (t *TrackingCategories) UpdateContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -72,7 +83,7 @@ func (t *TrackingCategories) Update(provider *xerogolang.Provider, session goth.
 		return nil, err
 	}
 
-	trackingCategoryResponseBytes, err := provider.Update(session, "TrackingCategories/"+t.TrackingCategories[0].TrackingCategoryID, additionalHeaders, body)
+	trackingCategoryResponseBytes, err := provider.UpdateContext(ctx, session, "TrackingCategories/"+t.TrackingCategories[0].TrackingCategoryID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -82,11 +93,16 @@ func (t *TrackingCategories) Update(provider *xerogolang.Provider, session goth.
 
 //FindTrackingCategories will get all trackingCategories
 func FindTrackingCategories(provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
+	return FindTrackingCategoriesContext(context.Background(), provider, session)
+}
+
+//FindTrackingCategoriesContext is like FindTrackingCategories but uses ctx for the request so that it can be cancelled or given a deadline
+func FindTrackingCategoriesContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	trackingCategoryResponseBytes, err := provider.Find(session, "TrackingCategories", additionalHeaders, nil)
+	trackingCategoryResponseBytes, err := provider.FindContext(ctx, session, "TrackingCategories", additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -96,11 +112,16 @@ func FindTrackingCategories(provider *xerogolang.Provider, session goth.Session)
 
 //FindTrackingCategory will get a single trackingCategory - trackingCategoryID must be a GUID for an trackingCategory
 func FindTrackingCategory(provider *xerogolang.Provider, session goth.Session, trackingCategoryID string) (*TrackingCategories, error) {
+	return FindTrackingCategoryContext(context.Background(), provider, session, trackingCategoryID)
+}
+
+//FindTrackingCategoryContext is like FindTrackingCategory but uses ctx for the request so that it can be cancelled or given a deadline
+func FindTrackingCategoryContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, trackingCategoryID string) (*TrackingCategories, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	trackingCategoryResponseBytes, err := provider.Find(session, "TrackingCategories/"+trackingCategoryID, additionalHeaders, nil)
+	trackingCategoryResponseBytes, err := provider.FindContext(ctx, session, "TrackingCategories/"+trackingCategoryID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -110,11 +131,16 @@ func FindTrackingCategory(provider *xerogolang.Provider, session goth.Session, t
 
 //RemoveTrackingCategory will get a single trackingCategory - trackingCategoryID must be a GUID for an trackingCategory
 func RemoveTrackingCategory(provider *xerogolang.Provider, session goth.Session, trackingCategoryID string) (*TrackingCategories, error) {
+	return RemoveTrackingCategoryContext(context.Background(), provider, session, trackingCategoryID)
+}
+
+//RemoveTrackingCategoryContext is like RemoveTrackingCategory but uses ctx for the request so that it can be cancelled or given a deadline
+func RemoveTrackingCategoryContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, trackingCategoryID string) (*TrackingCategories, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	trackingCategoryResponseBytes, err := provider.Remove(session, "TrackingCategories/"+trackingCategoryID, additionalHeaders)
+	trackingCategoryResponseBytes, err := provider.RemoveContext(ctx, session, "TrackingCategories/"+trackingCategoryID, additionalHeaders)
 	if err != nil {
 		return nil, err
 	}
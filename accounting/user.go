@@ -1,6 +1,7 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 
@@ -24,7 +25,7 @@ type User struct {
 	LastName string `json:"LastName,omitempty" xml:"LastName,omitempty"`
 
 	// Timestamp of last change to user
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"UpdatedDateUTC,omitempty"`
+	UpdatedDateUTC DateTime `json:"UpdatedDateUTC,omitempty" xml:"UpdatedDateUTC,omitempty"`
 
 	// Boolean to indicate if user is the subscriber
 	IsSubscriber bool `json:"IsSubscriber,omitempty" xml:"IsSubscriber,omitempty"`
@@ -51,6 +52,11 @@ func unmarshalUsers(userResponseBytes []byte) (*Users, error) {
 //FindUsersModifiedSince will get all users modified after a specified date
 //additional querystringParameters such as where and order can be added as a map
 func FindUsersModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Users, error) {
+	return FindUsersModifiedSinceContext(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindUsersModifiedSinceContext is like FindUsersModifiedSince but uses ctx for the request so that it can be cancelled or given a deadline
+func FindUsersModifiedSinceContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Users, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -59,7 +65,7 @@ func FindUsersModifiedSince(provider *xerogolang.Provider, session goth.Session,
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	userResponseBytes, err := provider.Find(session, "Users", additionalHeaders, querystringParameters)
+	userResponseBytes, err := provider.FindContext(ctx, session, "Users", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -70,16 +76,26 @@ func FindUsersModifiedSince(provider *xerogolang.Provider, session goth.Session,
 //FindUsers will get all users
 //additional querystringParameters such as where and order can be added as a map
 func FindUsers(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Users, error) {
-	return FindUsersModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindUsersContext(context.Background(), provider, session, querystringParameters)
+}
+
+//FindUsersContext is like FindUsers but uses ctx for the request so that it can be cancelled or given a deadline
+func FindUsersContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Users, error) {
+	return FindUsersModifiedSinceContext(ctx, provider, session, dayZero, querystringParameters)
 }
 
 //FindUser will get a single user - UserID must be a GUID for a user
 func FindUser(provider *xerogolang.Provider, session goth.Session, userID string) (*Users, error) {
+	return FindUserContext(context.Background(), provider, session, userID)
+}
+
+//FindUserContext is like FindUser but uses ctx for the request so that it can be cancelled or given a deadline
+func FindUserContext(ctx context.Context, provider *xerogolang.Provider, session goth.Session, userID string) (*Users, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	userResponseBytes, err := provider.Find(session, "Users/"+userID, additionalHeaders, nil)
+	userResponseBytes, err := provider.FindContext(ctx, session, "Users/"+userID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
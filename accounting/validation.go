@@ -0,0 +1,107 @@
+package accounting
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+//FieldError is a problem with one field of a document, found before it is sent to Xero
+type FieldError struct {
+	//Field is the path to the field e.g. Invoices[0].LineItems[1].AccountCode
+	Field string
+
+	//Message describes the problem
+	Message string
+}
+
+//Error returns the field and message
+func (f FieldError) Error() string {
+	return f.Field + ": " + f.Message
+}
+
+//ValidationErrors are all of the problems found when validating a document.
+//They are returned by Validate, and by Create and Update without sending anything to Xero
+type ValidationErrors []FieldError
+
+//Error lists every problem
+func (v ValidationErrors) Error() string {
+	messages := make([]string, len(v))
+	for n, fieldError := range v {
+		messages[n] = fieldError.Error()
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+//validator collects FieldErrors for the fields of a document
+type validator struct {
+	errors ValidationErrors
+}
+
+//field returns the path of a field of the n'th element of a collection e.g. Invoices[0].Status
+func field(collection string, n int, name string) string {
+	return fmt.Sprintf("%s[%d].%s", collection, n, name)
+}
+
+//add records a problem with a field
+func (v *validator) add(field string, format string, args ...interface{}) {
+	v.errors = append(v.errors, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+//check records err against field if it isn't nil
+func (v *validator) check(field string, err error) {
+	if err != nil {
+		v.add(field, "%s", err.Error())
+	}
+}
+
+//err returns the problems found, or nil if there weren't any
+func (v *validator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return v.errors
+}
+
+//required records a problem if a field that must be set for a new document is missing
+func (v *validator) required(field string, present bool) {
+	if !present {
+		v.add(field, "is required")
+	}
+}
+
+//maxLength records a problem if value is longer than Xero allows
+func (v *validator) maxLength(field string, value string, max int) {
+	if length := utf8.RuneCountInString(value); length > max {
+		v.add(field, "must be at most %d characters but is %d", max, length)
+	}
+}
+
+//contact checks that a document refers to a contact Xero can find or create
+func (v *validator) contact(field string, contact Contact) {
+	if contact.ContactID == "" && contact.Name == "" {
+		v.add(field, "a ContactID or Name is required")
+	}
+	v.maxLength(field+".Name", contact.Name, 255)
+}
+
+//tracking checks the tracking categories of a line
+func (v *validator) tracking(field string, tracking []TrackingCategory) {
+	if len(tracking) > 2 {
+		v.add(field, "can have at most 2 tracking categories but has %d", len(tracking))
+	}
+}
+
+//lineItems checks the line items of a document. Discounts are only allowed on sales documents
+func (v *validator) lineItems(field string, lineItems []LineItem, discountAllowed bool) {
+	for n, lineItem := range lineItems {
+		lineField := fmt.Sprintf("%s[%d]", field, n)
+		if lineItem.LineItemID == "" && lineItem.Description == "" && lineItem.ItemCode == "" {
+			v.add(lineField+".Description", "a Description or ItemCode is required")
+		}
+		v.tracking(lineField+".Tracking", lineItem.Tracking)
+		if !discountAllowed && !lineItem.DiscountRate.IsZero() {
+			v.add(lineField+".DiscountRate", "discounts are only supported on ACCREC invoices, ACCRECCREDIT credit notes and quotes")
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package accounting
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Validate_Examples(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	a.NoError(GenerateExampleInvoice().Validate())
+	a.NoError(GenerateExampleCreditNote().Validate())
+	a.NoError(GenerateExampleBankTransaction().Validate())
+	a.NoError(GenerateExampleManualJournal().Validate())
+	a.NoError(GenerateExamplePurchaseOrder("contact-id").Validate())
+	a.NoError(GenerateExamplePayment("invoice-id", MustParseDecimal("395.00")).Validate())
+}
+
+func Test_Invoices_Validate_Required(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	invoices := &Invoices{Invoices: []Invoice{{
+		Status:        InvoiceStatusAuthorised,
+		InvoiceNumber: strings.Repeat("é", 256),
+	}}}
+	a.Equal(ValidationErrors{
+		{Field: "Invoices[0].InvoiceNumber", Message: "must be at most 255 characters but is 256"},
+		{Field: "Invoices[0].Type", Message: "is required"},
+		{Field: "Invoices[0].Contact", Message: "a ContactID or Name is required"},
+		{Field: "Invoices[0].LineItems", Message: "is required"},
+	}, invoices.Validate())
+
+	//Updating an existing invoice only needs the fields being changed
+	invoices = &Invoices{Invoices: []Invoice{{InvoiceID: "invoice-id", Status: InvoiceStatusVoided}}}
+	a.NoError(invoices.Validate())
+
+	//A draft can be created before it has any line items
+	invoices = &Invoices{Invoices: []Invoice{{Type: InvoiceTypeAccPay, Contact: Contact{Name: "Supplier"}, Status: InvoiceStatusDraft}}}
+	a.NoError(invoices.Validate())
+}
+
+func Test_Invoices_Validate_LineItems(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	invoices := &Invoices{Invoices: []Invoice{{
+		Type:    InvoiceTypeAccPay,
+		Contact: Contact{ContactID: "contact-id"},
+		LineItems: []LineItem{
+			{Description: "Consulting", DiscountRate: MustParseDecimal("10")},
+			{Tracking: []TrackingCategory{{Name: "Region"}, {Name: "Team"}, {Name: "Project"}}},
+		},
+	}}}
+	a.Equal(ValidationErrors{
+		{Field: "Invoices[0].LineItems[0].DiscountRate", Message: "discounts are only supported on ACCREC invoices, ACCRECCREDIT credit notes and quotes"},
+		{Field: "Invoices[0].LineItems[1].Description", Message: "a Description or ItemCode is required"},
+		{Field: "Invoices[0].LineItems[1].Tracking", Message: "can have at most 2 tracking categories but has 3"},
+	}, invoices.Validate())
+}
+
+func Test_Validate_Discounts(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	discounted := []LineItem{{Description: "Consulting", DiscountRate: MustParseDecimal("10")}}
+
+	//An update that only changes the line items doesn't need to send the Type
+	invoices := &Invoices{Invoices: []Invoice{{InvoiceID: "invoice-id", LineItems: discounted}}}
+	a.NoError(invoices.Validate())
+
+	repeatingInvoices := &RepeatingInvoices{RepeatingInvoices: []RepeatingInvoice{{RepeatingInvoiceID: "repeating-invoice-id", LineItems: discounted}}}
+	a.NoError(repeatingInvoices.Validate())
+
+	creditNotes := &CreditNotes{CreditNotes: []CreditNote{{Type: CreditNoteTypeAccRecCredit, Contact: Contact{ContactID: "contact-id"}, LineItems: discounted}}}
+	a.NoError(creditNotes.Validate())
+
+	creditNotes = &CreditNotes{CreditNotes: []CreditNote{{CreditNoteID: "credit-note-id", LineItems: discounted}}}
+	a.NoError(creditNotes.Validate())
+
+	creditNotes = &CreditNotes{CreditNotes: []CreditNote{{Type: CreditNoteTypeAccPayCredit, Contact: Contact{ContactID: "contact-id"}, LineItems: discounted}}}
+	a.Equal(ValidationErrors{
+		{Field: "CreditNotes[0].LineItems[0].DiscountRate", Message: "discounts are only supported on ACCREC invoices, ACCRECCREDIT credit notes and quotes"},
+	}, creditNotes.Validate())
+}
+
+func Test_ManualJournals_Validate(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	manualJournals := &ManualJournals{ManualJournals: []ManualJournal{{
+		JournalLines: []ManualJournalLine{
+			{AccountCode: "200", LineAmount: MustParseDecimal("100.10")},
+			{LineAmount: MustParseDecimal("-100")},
+		},
+	}}}
+	a.Equal(ValidationErrors{
+		{Field: "ManualJournals[0].JournalLines[1].AccountCode", Message: "is required"},
+		{Field: "ManualJournals[0].JournalLines", Message: "debits and credits must balance but the LineAmounts add up to 0.1"},
+		{Field: "ManualJournals[0].Narration", Message: "is required"},
+	}, manualJournals.Validate())
+
+	_, err := manualJournals.Create(nil, nil)
+	a.Error(err)
+}
+
+func Test_Payments_Validate(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	payments := &Payments{Payments: []Payment{{Amount: MustParseDecimal("-5")}}}
+	a.Equal(ValidationErrors{
+		{Field: "Payments[0].Invoice", Message: "an Invoice or CreditNote is required"},
+		{Field: "Payments[0].Account", Message: "is required"},
+		{Field: "Payments[0].Date", Message: "is required"},
+		{Field: "Payments[0].Amount", Message: "must be more than zero"},
+	}, payments.Validate())
+
+	payments = &Payments{Payments: []Payment{{PaymentID: "payment-id", Status: PaymentStatusDeleted}}}
+	a.NoError(payments.Validate())
+}
@@ -0,0 +1,73 @@
+package xerogolang
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/mrjones/oauth"
+	"github.com/stretchr/testify/assert"
+)
+
+//These tests are most useful when run with the race detector: go test -race
+
+func Test_New_Concurrent(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var wg sync.WaitGroup
+	providers := make([]*Provider, 20)
+	for n := range providers {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			if n%2 == 0 {
+				providers[n] = New(os.Getenv("XERO_KEY"), os.Getenv("XERO_SECRET"), "/foo")
+			} else {
+				providers[n] = NewNoEnviro(fmt.Sprintf("KEY%d", n), "SECRET", "/foo", fmt.Sprintf("App%d", n), "public", nil)
+			}
+		}(n)
+	}
+	wg.Wait()
+
+	for n, provider := range providers {
+		if n%2 == 0 {
+			a.Equal(userAgentFor(os.Getenv("XERO_USER_AGENT"), os.Getenv("XERO_KEY")), provider.UserAgentString)
+		} else {
+			a.Equal(fmt.Sprintf("App%d (xerogolang 0.1.3) KEY%d", n, n), provider.UserAgentString)
+			a.Equal(fmt.Sprintf("KEY%d", n), provider.ClientKey)
+		}
+	}
+}
+
+func Test_Find_Concurrent(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockProvider(ts)
+
+		additionalHeaders := map[string]string{
+			"Accept": "application/json",
+		}
+
+		var wg sync.WaitGroup
+		errs := make([]error, 20)
+		for n := range errs {
+			wg.Add(1)
+			go func(n int) {
+				defer wg.Done()
+				session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+				_, errs[n] = provider.Find(&session, "TrackingCategories", additionalHeaders, nil)
+				provider.RateLimit("")
+			}(n)
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			a.NoError(err)
+		}
+	})
+}
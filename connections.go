@@ -0,0 +1,122 @@
+package xerogolang
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/markbates/goth"
+)
+
+//Connection is an organisation (tenant) that an OAuth 2.0 token has been granted access to
+//See https://developer.xero.com/documentation/oauth2/auth-flow#connections
+type Connection struct {
+	// The identifier of the connection, used to disconnect the tenant
+	ID string `json:"id"`
+
+	// The identifier of the authorisation that created the connection
+	AuthEventID string `json:"authEventId,omitempty"`
+
+	// The identifier to send as the Xero-tenant-id header
+	TenantID string `json:"tenantId"`
+
+	// The type of tenant e.g. ORGANISATION, PRACTICE
+	TenantType string `json:"tenantType,omitempty"`
+
+	// The name of the organisation
+	TenantName string `json:"tenantName,omitempty"`
+
+	// When the connection was created
+	CreatedDateUTC string `json:"createdDateUtc,omitempty"`
+
+	// When the connection was last updated
+	UpdatedDateUTC string `json:"updatedDateUtc,omitempty"`
+}
+
+//FindConnections returns the tenants the session's OAuth 2.0 token can access.
+//Set Session.TenantID to the TenantID of one of them to choose which organisation requests are sent to
+func (p *Provider) FindConnections(session goth.Session) ([]Connection, error) {
+	return p.FindConnectionsContext(context.Background(), session)
+}
+
+//FindConnectionsContext is like FindConnections but uses ctx for the request so that it can be cancelled or given a deadline
+func (p *Provider) FindConnectionsContext(ctx context.Context, session goth.Session) ([]Connection, error) {
+	if !p.isOAuth2() {
+		return nil, errors.New("Connections are only available to OAuth 2.0 providers")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "GET", p.endpoints().ConnectionsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	connectionResponseBytes, err := p.processRequest(request, session, "connections", additionalHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	var connections []Connection
+	err = json.Unmarshal(connectionResponseBytes, &connections)
+	if err != nil {
+		return nil, err
+	}
+
+	return connections, nil
+}
+
+//RemoveConnection disconnects a tenant so the session's token can no longer access it.
+//If it was the session's selected tenant the TenantID is cleared
+func (p *Provider) RemoveConnection(session goth.Session, connection Connection) error {
+	return p.RemoveConnectionContext(context.Background(), session, connection)
+}
+
+//RemoveConnectionContext is like RemoveConnection but uses ctx for the request so that it can be cancelled or given a deadline
+func (p *Provider) RemoveConnectionContext(ctx context.Context, session goth.Session, connection Connection) error {
+	if !p.isOAuth2() {
+		return errors.New("Connections are only available to OAuth 2.0 providers")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "DELETE", p.endpoints().ConnectionsURL+"/"+connection.ID, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.processRequest(request, session, "connections/"+connection.ID, nil)
+	if err != nil {
+		return err
+	}
+
+	sess := session.(*Session)
+	if connection.TenantID != "" && connection.TenantID == sess.TenantID {
+		sess.TenantID = ""
+	}
+
+	return nil
+}
+
+//selectDefaultTenant chooses the first organisation the session can access if no tenant has been selected yet
+//so that applications connecting to a single organisation don't need to deal with connections
+func (p *Provider) selectDefaultTenant(ctx context.Context, session *Session) error {
+	if session.TenantID != "" {
+		return nil
+	}
+
+	connections, err := p.FindConnectionsContext(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	for _, connection := range connections {
+		if connection.TenantType == "" || connection.TenantType == "ORGANISATION" {
+			session.TenantID = connection.TenantID
+			return nil
+		}
+	}
+
+	return nil
+}
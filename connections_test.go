@@ -0,0 +1,66 @@
+package xerogolang
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FindConnections(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockOAuth2Provider(ts)
+		session := &Session{OAuth2AccessToken: "ACCESS", AccessTokenExpires: time.Now().UTC().Add(30 * time.Minute)}
+
+		connections, err := provider.FindConnections(session)
+		a.NoError(err)
+		a.Len(connections, 2)
+		a.Equal("conn-2", connections[1].ID)
+		a.Equal("tenant-2", connections[1].TenantID)
+		a.Equal("Kramerica", connections[1].TenantName)
+
+		_, err = xeroProvider().FindConnections(session)
+		a.Error(err)
+	})
+}
+
+func Test_RemoveConnection(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockOAuth2Provider(ts)
+		session := &Session{OAuth2AccessToken: "ACCESS", TenantID: "tenant-2", AccessTokenExpires: time.Now().UTC().Add(30 * time.Minute)}
+
+		err := provider.RemoveConnection(session, Connection{ID: "conn-1", TenantID: "tenant-1"})
+		a.NoError(err)
+		a.Equal("tenant-2", session.TenantID)
+
+		err = provider.RemoveConnection(session, Connection{ID: "conn-2", TenantID: "tenant-2"})
+		a.NoError(err)
+		a.Equal("", session.TenantID)
+	})
+}
+
+func Test_Find_SendsTenantID(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockOAuth2Provider(ts)
+		session := &Session{OAuth2AccessToken: "ACCESS", TenantID: "tenant-2", AccessTokenExpires: time.Now().UTC().Add(30 * time.Minute)}
+
+		response, err := provider.Find(session, "Tenant", map[string]string{"Accept": "application/json"}, nil)
+		a.NoError(err)
+
+		var testResponse *Tests
+		err = json.Unmarshal(response, &testResponse)
+		a.NoError(err)
+		a.Equal("tenant-2", testResponse.Tests[0].Name)
+	})
+}
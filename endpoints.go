@@ -0,0 +1,250 @@
+package xerogolang
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/markbates/goth"
+)
+
+//API identifies one of the Xero APIs. Its value is the path of the API relative to Endpoints.APIBaseURL
+type API string
+
+const (
+	//AccountingAPI is the Accounting API used by the accounting package
+	AccountingAPI API = "api.xro/2.0/"
+	//PayrollAUAPI is the Payroll API for Australian organisations
+	PayrollAUAPI API = "payroll.xro/1.0/"
+	//PayrollUKAPI is the Payroll API for UK and New Zealand organisations
+	PayrollUKAPI API = "payroll.xro/2.0/"
+	//FilesAPI is the Files API
+	FilesAPI API = "files.xro/1.0/"
+	//AssetsAPI is the Fixed Assets API
+	AssetsAPI API = "assets.xro/1.0/"
+	//ProjectsAPI is the Projects API
+	ProjectsAPI API = "projects.xro/2.0/"
+)
+
+//Endpoints are the URLs a Provider talks to. Any that are left empty use the value from DefaultEndpoints
+type Endpoints struct {
+	//APIBaseURL is the root that each API's path is added to
+	APIBaseURL string
+
+	//APIRoots overrides the root URL of individual APIs e.g. to send Payroll requests somewhere else
+	APIRoots map[API]string
+
+	//RequestTokenURL, AuthorizeURL and AccessTokenURL are used by OAuth 1.0a providers
+	RequestTokenURL string
+	AuthorizeURL    string
+	AccessTokenURL  string
+
+	//OAuth2AuthorizeURL, OAuth2TokenURL and OAuth2RevocationURL are used by OAuth 2.0 providers
+	OAuth2AuthorizeURL  string
+	OAuth2TokenURL      string
+	OAuth2RevocationURL string
+
+	//ConnectionsURL lists the tenants an OAuth 2.0 token can access
+	ConnectionsURL string
+}
+
+//DefaultEndpoints returns the endpoints of the production Xero API
+func DefaultEndpoints() Endpoints {
+	return Endpoints{
+		APIBaseURL:          "https://api.xero.com/",
+		RequestTokenURL:     "https://api.xero.com/oauth/RequestToken",
+		AuthorizeURL:        "https://api.xero.com/oauth/Authorize",
+		AccessTokenURL:      "https://api.xero.com/oauth/AccessToken",
+		OAuth2AuthorizeURL:  "https://login.xero.com/identity/connect/authorize",
+		OAuth2TokenURL:      "https://identity.xero.com/connect/token",
+		OAuth2RevocationURL: "https://identity.xero.com/connect/revocation",
+		ConnectionsURL:      "https://api.xero.com/connections",
+	}
+}
+
+//EndpointsForBaseURL returns endpoints with the same paths as the Xero API but all served from baseURL.
+//This is useful for pointing a Provider at a local stand-in for Xero
+func EndpointsForBaseURL(baseURL string) Endpoints {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return Endpoints{
+		APIBaseURL:          baseURL + "/",
+		RequestTokenURL:     baseURL + "/oauth/RequestToken",
+		AuthorizeURL:        baseURL + "/oauth/Authorize",
+		AccessTokenURL:      baseURL + "/oauth/AccessToken",
+		OAuth2AuthorizeURL:  baseURL + "/identity/connect/authorize",
+		OAuth2TokenURL:      baseURL + "/connect/token",
+		OAuth2RevocationURL: baseURL + "/connect/revocation",
+		ConnectionsURL:      baseURL + "/connections",
+	}
+}
+
+//withDefaults fills any empty endpoints with the production values
+func (e Endpoints) withDefaults() Endpoints {
+	defaults := DefaultEndpoints()
+	fill := func(value *string, fallback string) {
+		if *value == "" {
+			*value = fallback
+		}
+	}
+	fill(&e.APIBaseURL, defaults.APIBaseURL)
+	fill(&e.RequestTokenURL, defaults.RequestTokenURL)
+	fill(&e.AuthorizeURL, defaults.AuthorizeURL)
+	fill(&e.AccessTokenURL, defaults.AccessTokenURL)
+	fill(&e.OAuth2AuthorizeURL, defaults.OAuth2AuthorizeURL)
+	fill(&e.OAuth2TokenURL, defaults.OAuth2TokenURL)
+	fill(&e.OAuth2RevocationURL, defaults.OAuth2RevocationURL)
+	fill(&e.ConnectionsURL, defaults.ConnectionsURL)
+	return e
+}
+
+//APIRoot returns the URL that endpoints of the given API are relative to
+func (e Endpoints) APIRoot(api API) string {
+	if root, ok := e.APIRoots[api]; ok && root != "" {
+		if !strings.HasSuffix(root, "/") {
+			root = root + "/"
+		}
+		return root
+	}
+	baseURL := e.withDefaults().APIBaseURL
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL = baseURL + "/"
+	}
+	return baseURL + string(api)
+}
+
+//endpoints returns the provider's endpoints with defaults filled in
+func (p *Provider) endpoints() Endpoints {
+	return p.Endpoints.withDefaults()
+}
+
+//APIClient sends requests to one of the Xero APIs using the authentication, retries and middleware of a Provider
+type APIClient struct {
+	provider *Provider
+	root     string
+}
+
+//API returns a client for sending requests to the given Xero API e.g. provider.API(xerogolang.PayrollAUAPI)
+func (p *Provider) API(api API) *APIClient {
+	return &APIClient{
+		provider: p,
+		root:     p.endpoints().APIRoot(api),
+	}
+}
+
+//Find retrieves the requested data from an endpoint to be unmarshaled into the appropriate data type
+func (a *APIClient) Find(session goth.Session, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string) ([]byte, error) {
+	return a.FindContext(context.Background(), session, endpoint, additionalHeaders, querystringParameters)
+}
+
+//FindContext is like Find but uses ctx for the request so that it can be cancelled or given a deadline
+func (a *APIClient) FindContext(ctx context.Context, session goth.Session, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, "GET", a.root+endpoint+querystring(querystringParameters), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.provider.processRequest(request, session, endpoint, additionalHeaders)
+}
+
+//FindStream is like Find but returns the body of the response as it arrives instead of reading it into memory.
+//It is meant for large or binary responses such as attachments and PDFs. The caller must close the returned reader
+func (a *APIClient) FindStream(session goth.Session, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string) (io.ReadCloser, error) {
+	return a.FindStreamContext(context.Background(), session, endpoint, additionalHeaders, querystringParameters)
+}
+
+//FindStreamContext is like FindStream but uses ctx for the request so that it can be cancelled or given a deadline.
+//Cancelling ctx also stops reading the body
+func (a *APIClient) FindStreamContext(ctx context.Context, session goth.Session, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string) (io.ReadCloser, error) {
+	request, err := http.NewRequestWithContext(ctx, "GET", a.root+endpoint+querystring(querystringParameters), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := a.provider.doRequest(request, session, endpoint, additionalHeaders)
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}
+
+//Send sends body to an endpoint using any HTTP method along with querystring parameters,
+//for requests that Create and Update can't express such as uploading a file
+func (a *APIClient) Send(session goth.Session, method string, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string, body []byte) ([]byte, error) {
+	return a.SendContext(context.Background(), session, method, endpoint, additionalHeaders, querystringParameters, body)
+}
+
+//SendContext is like Send but uses ctx for the request so that it can be cancelled or given a deadline
+func (a *APIClient) SendContext(ctx context.Context, session goth.Session, method string, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string, body []byte) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, method, a.root+endpoint+querystring(querystringParameters), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	return a.provider.processRequest(request, session, endpoint, additionalHeaders)
+}
+
+//querystring encodes querystringParameters, including the leading "?", or returns an empty string if there are none
+func querystring(querystringParameters map[string]string) string {
+	if querystringParameters == nil {
+		return ""
+	}
+	var querystring string
+	for key, value := range querystringParameters {
+		escapedValue := url.QueryEscape(value)
+		querystring = querystring + "&" + key + "=" + escapedValue
+	}
+	querystring = strings.TrimPrefix(querystring, "&")
+	return "?" + querystring
+}
+
+//Create sends data to an endpoint and returns a response to be unmarshaled into the appropriate data type
+func (a *APIClient) Create(session goth.Session, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
+	return a.CreateContext(context.Background(), session, endpoint, additionalHeaders, body)
+}
+
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (a *APIClient) CreateContext(ctx context.Context, session goth.Session, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
+	bodyReader := bytes.NewReader(body)
+
+	request, err := http.NewRequestWithContext(ctx, "PUT", a.root+endpoint, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.provider.processRequest(request, session, endpoint, additionalHeaders)
+}
+
+//Update sends data to an endpoint and returns a response to be unmarshaled into the appropriate data type
+func (a *APIClient) Update(session goth.Session, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
+	return a.UpdateContext(context.Background(), session, endpoint, additionalHeaders, body)
+}
+
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (a *APIClient) UpdateContext(ctx context.Context, session goth.Session, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
+	bodyReader := bytes.NewReader(body)
+
+	request, err := http.NewRequestWithContext(ctx, "POST", a.root+endpoint, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.provider.processRequest(request, session, endpoint, additionalHeaders)
+}
+
+//Remove deletes the specified data from an endpoint
+func (a *APIClient) Remove(session goth.Session, endpoint string, additionalHeaders map[string]string) ([]byte, error) {
+	return a.RemoveContext(context.Background(), session, endpoint, additionalHeaders)
+}
+
+//RemoveContext is like Remove but uses ctx for the request so that it can be cancelled or given a deadline
+func (a *APIClient) RemoveContext(ctx context.Context, session goth.Session, endpoint string, additionalHeaders map[string]string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, "DELETE", a.root+endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.provider.processRequest(request, session, endpoint, additionalHeaders)
+}
@@ -0,0 +1,64 @@
+package xerogolang
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mrjones/oauth"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Endpoints_Defaults(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	provider := xeroProvider()
+	a.Equal(DefaultEndpoints(), provider.endpoints())
+	a.Equal("https://api.xero.com/api.xro/2.0/", provider.endpoints().APIRoot(AccountingAPI))
+	a.Equal("https://api.xero.com/payroll.xro/1.0/", provider.endpoints().APIRoot(PayrollAUAPI))
+
+	provider.Endpoints = Endpoints{
+		APIRoots: map[API]string{
+			FilesAPI: "http://localhost:8080/files",
+		},
+	}
+	a.Equal("http://localhost:8080/files/", provider.endpoints().APIRoot(FilesAPI))
+	a.Equal("https://api.xero.com/api.xro/2.0/", provider.endpoints().APIRoot(AccountingAPI))
+	a.Equal("https://identity.xero.com/connect/token", provider.endpoints().OAuth2TokenURL)
+}
+
+func Test_Endpoints_PerProvider(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		payroll := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			a.Equal("/payroll.xro/1.0/Employees", req.URL.Path)
+			fmt.Fprint(res, `{"TrackingCategories":[{"Name":"Payroll"}]}`)
+		}))
+		defer payroll.Close()
+
+		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+		additionalHeaders := map[string]string{
+			"Accept": "application/json",
+		}
+
+		accountingProvider := mockProvider(ts)
+		payrollProvider := xeroProvider()
+		payrollProvider.Endpoints = EndpointsForBaseURL(payroll.URL)
+
+		response, err := accountingProvider.Find(&session, "TrackingCategories", additionalHeaders, nil)
+		a.NoError(err)
+		var testResponse *Tests
+		a.NoError(json.Unmarshal(response, &testResponse))
+		a.Equal("Store", testResponse.Tests[0].Name)
+
+		response, err = payrollProvider.API(PayrollAUAPI).Find(&session, "Employees", additionalHeaders, nil)
+		a.NoError(err)
+		a.NoError(json.Unmarshal(response, &testResponse))
+		a.Equal("Payroll", testResponse.Tests[0].Name)
+	})
+}
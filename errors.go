@@ -0,0 +1,145 @@
+package xerogolang
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/XeroAPI/xerogolang/helpers"
+)
+
+//APIError is returned by the Provider whenever the Xero API responds with anything other than a 200.
+//Use errors.As to get at the details, or one of the Is helpers such as IsNotFound to check the kind of error
+type APIError struct {
+	//StatusCode is the HTTP status returned by the API
+	StatusCode int
+
+	//ErrorNumber is the Xero error number e.g. 10 for a ValidationException
+	ErrorNumber int `json:"ErrorNumber"`
+
+	//Type is the Xero exception type e.g. ValidationException, QueryParseException
+	Type string `json:"Type"`
+
+	//Message is the description of the error returned by Xero
+	Message string `json:"Message"`
+
+	//ValidationErrors are the problems Xero found with the elements that were sent in the request
+	ValidationErrors []ValidationError
+
+	//OAuthProblem is set when the request was rejected by the OAuth layer e.g. token_expired, rate limit exceeded
+	OAuthProblem string
+
+	//Body is the raw response body
+	Body string
+}
+
+//ValidationError is a single validation message returned by Xero
+type ValidationError struct {
+	//Element is the position of the element in the request that failed validation
+	Element int
+
+	//Message describes what was invalid
+	Message string `json:"Message"`
+}
+
+//apiErrorResponse is the body Xero returns for an ApiException
+type apiErrorResponse struct {
+	ErrorNumber int    `json:"ErrorNumber"`
+	Type        string `json:"Type"`
+	Message     string `json:"Message"`
+	Elements    []struct {
+		ValidationErrors []ValidationError `json:"ValidationErrors"`
+	} `json:"Elements"`
+}
+
+//newAPIError builds an APIError from a response - the body will be consumed
+func newAPIError(response *http.Response) *APIError {
+	body := helpers.ReaderToString(response.Body)
+	apiError := &APIError{
+		StatusCode: response.StatusCode,
+		Body:       body,
+	}
+
+	trimmedBody := strings.TrimSpace(body)
+	switch {
+	case strings.HasPrefix(trimmedBody, "{"):
+		var errorResponse apiErrorResponse
+		if json.Unmarshal([]byte(trimmedBody), &errorResponse) == nil {
+			apiError.ErrorNumber = errorResponse.ErrorNumber
+			apiError.Type = errorResponse.Type
+			apiError.Message = errorResponse.Message
+			for n, element := range errorResponse.Elements {
+				for _, validationError := range element.ValidationErrors {
+					validationError.Element = n
+					apiError.ValidationErrors = append(apiError.ValidationErrors, validationError)
+				}
+			}
+		}
+	case strings.HasPrefix(trimmedBody, "oauth_problem="):
+		values, err := url.ParseQuery(trimmedBody)
+		if err == nil {
+			apiError.OAuthProblem = values.Get("oauth_problem")
+			apiError.Message = values.Get("oauth_problem_advice")
+		}
+	}
+
+	return apiError
+}
+
+func (e *APIError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = strings.TrimSpace(e.Body)
+	}
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+	if e.OAuthProblem != "" {
+		message = e.OAuthProblem + ": " + message
+	}
+
+	if len(e.ValidationErrors) > 0 {
+		validationMessages := make([]string, 0, len(e.ValidationErrors))
+		for _, validationError := range e.ValidationErrors {
+			validationMessages = append(validationMessages, validationError.Message)
+		}
+		message = message + ": " + strings.Join(validationMessages, "; ")
+	}
+
+	return fmt.Sprintf("xero: %d %s", e.StatusCode, message)
+}
+
+func asAPIError(err error) (*APIError, bool) {
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		return apiError, true
+	}
+	return nil, false
+}
+
+//IsNotFound reports whether err is an APIError for a resource that does not exist
+func IsNotFound(err error) bool {
+	apiError, ok := asAPIError(err)
+	return ok && apiError.StatusCode == http.StatusNotFound
+}
+
+//IsRateLimited reports whether err is an APIError caused by exceeding one of the Xero API limits
+func IsRateLimited(err error) bool {
+	apiError, ok := asAPIError(err)
+	return ok && (apiError.StatusCode == http.StatusTooManyRequests || apiError.OAuthProblem == "rate limit exceeded")
+}
+
+//IsUnauthorized reports whether err is an APIError caused by a missing, invalid or expired token
+func IsUnauthorized(err error) bool {
+	apiError, ok := asAPIError(err)
+	return ok && apiError.StatusCode == http.StatusUnauthorized
+}
+
+//IsValidationError reports whether err is an APIError caused by Xero rejecting the data that was sent
+func IsValidationError(err error) bool {
+	apiError, ok := asAPIError(err)
+	return ok && (apiError.Type == "ValidationException" || len(apiError.ValidationErrors) > 0)
+}
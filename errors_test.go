@@ -0,0 +1,83 @@
+package xerogolang
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mrjones/oauth"
+	"github.com/stretchr/testify/assert"
+)
+
+func errorResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func Test_APIError_ValidationException(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	body := `{
+		"ErrorNumber": 10,
+		"Type": "ValidationException",
+		"Message": "A validation exception occurred",
+		"Elements": [
+			{"ValidationErrors": [{"Message": "Email address must be valid."}]},
+			{"ValidationErrors": [{"Message": "Account code '999' is not a valid code for this document."}, {"Message": "Invoice not of valid status for modification"}]}
+		]
+	}`
+
+	err := error(newAPIError(errorResponse(http.StatusBadRequest, body)))
+	apiError, ok := asAPIError(fmt.Errorf("creating invoice: %w", err))
+	a.True(ok)
+	a.Equal(http.StatusBadRequest, apiError.StatusCode)
+	a.Equal(10, apiError.ErrorNumber)
+	a.Equal("ValidationException", apiError.Type)
+	a.Equal("A validation exception occurred", apiError.Message)
+	a.Len(apiError.ValidationErrors, 3)
+	a.Equal(0, apiError.ValidationErrors[0].Element)
+	a.Equal(1, apiError.ValidationErrors[2].Element)
+	a.Equal("Invoice not of valid status for modification", apiError.ValidationErrors[2].Message)
+	a.True(IsValidationError(err))
+	a.False(IsNotFound(err))
+	a.Contains(err.Error(), "Email address must be valid.")
+}
+
+func Test_APIError_OAuthProblem(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	err := newAPIError(errorResponse(http.StatusUnauthorized, "oauth_problem=token_expired&oauth_problem_advice=The%20access%20token%20has%20expired"))
+	a.Equal("token_expired", err.OAuthProblem)
+	a.Equal("The access token has expired", err.Message)
+	a.True(IsUnauthorized(err))
+	a.False(IsRateLimited(err))
+
+	err = newAPIError(errorResponse(http.StatusServiceUnavailable, "oauth_problem=rate%20limit%20exceeded&oauth_problem_advice=please%20wait%20before%20retrying%20the%20xero%20api"))
+	a.True(IsRateLimited(err))
+}
+
+func Test_Find_NotFound(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockProvider(ts)
+		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+
+		additionalHeaders := map[string]string{
+			"Accept": "application/json",
+		}
+
+		_, err := provider.Find(&session, "Missing", additionalHeaders, nil)
+		a.Error(err)
+		a.True(IsNotFound(err))
+		a.Equal("xero: 404 The resource you're looking for cannot be found", err.Error())
+	})
+}
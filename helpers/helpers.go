@@ -4,8 +4,6 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"io/ioutil"
-	"log"
 	"regexp"
 	"strconv"
 	"strings"
@@ -22,12 +20,7 @@ func ReaderToString(reader io.ReadCloser) string {
 	if err != nil {
 		return ""
 	}
-	newString := buf.String()
-	_, err = fmt.Printf(newString)
-	if err != nil {
-		return ""
-	}
-	return newString
+	return buf.String()
 }
 
 func getTimestampAndOffset(regex *regexp.Regexp, timeString string) (int64, int64, error) {
@@ -95,6 +88,23 @@ func DotNetJSONTimeToRFC3339(jsonTime string, isUTC bool) (string, error) {
 	}
 }
 
+//dotNetJSONTime matches the .Net JSON date format e.g. /Date(1494201600000+0000)/
+var dotNetJSONTime = regexp.MustCompile(`^/Date\((-?[0-9]+)([+-][0-9]{4})?\)/$`)
+
+//ParseDotNetJSONTime parses the .Net formatted time returned by the Xero API.
+//The number is milliseconds since the Unix epoch in UTC so any offset after it is ignored
+func ParseDotNetJSONTime(jsonTime string) (time.Time, error) {
+	matches := dotNetJSONTime.FindStringSubmatch(jsonTime)
+	if matches == nil {
+		return time.Time{}, fmt.Errorf("%q is not a .Net JSON date", jsonTime)
+	}
+	milliseconds, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(milliseconds).UTC(), nil
+}
+
 //TodayRFC3339 returns an RFC3339 formatted date
 //with a 0 valued time as required by many Xero endpoints
 func TodayRFC3339() string {
@@ -110,16 +120,16 @@ func FormatDate(date time.Time) string {
 	return strings.TrimSuffix(d.Format(time.RFC3339), "Z")
 }
 
+//ReadPrivateKeyFromPath reads a .pem private key file, returning an empty string if it can't be read.
+//
+//Deprecated: the error is discarded - use LoadPrivateKeyFromPath instead
 func ReadPrivateKeyFromPath(privateKeyFilePath string) string {
 	if privateKeyFilePath == "" {
 		return ""
 	}
 
-	privateKeyFileContents, err := ioutil.ReadFile(privateKeyFilePath)
-	if err != nil {
-		log.Fatal(err)
-	}
-	return string(privateKeyFileContents)
+	privateKey, _ := LoadPrivateKeyFromPath(privateKeyFilePath)
+	return privateKey
 }
 
 func StringInSlice(str string, list []string) bool {
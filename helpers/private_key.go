@@ -0,0 +1,227 @@
+package helpers
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+//LoadPrivateKeyFromPath reads a .pem private key file, returning an error if it cannot be read
+func LoadPrivateKeyFromPath(privateKeyFilePath string) (string, error) {
+	if privateKeyFilePath == "" {
+		return "", errors.New("no private key file path was provided")
+	}
+
+	privateKeyFileContents, err := ioutil.ReadFile(privateKeyFilePath)
+	if err != nil {
+		return "", fmt.Errorf("could not read private key: %s", err.Error())
+	}
+	return string(privateKeyFileContents), nil
+}
+
+//errDecryptPrivateKey is returned for every failure to decrypt a private key, so that the error
+//doesn't reveal whether the padding or the key inside it was wrong
+var errDecryptPrivateKey = errors.New("could not decrypt private key: incorrect passphrase or corrupt key")
+
+//ParseRSAPrivateKey parses a PEM encoded RSA private key. PKCS#1 (BEGIN RSA PRIVATE KEY) and
+//PKCS#8 (BEGIN PRIVATE KEY) keys are supported, as are keys encrypted with a passphrase as
+//PKCS#8 (BEGIN ENCRYPTED PRIVATE KEY).
+//
+//Keys encrypted in the legacy OpenSSL format (Proc-Type: 4,ENCRYPTED) are still read but this is deprecated:
+//the format is insecure by design and support will be removed. Convert them to PKCS#8 with
+//openssl pkcs8 -topk8 -v2 aes-256-cbc -in privatekey.pem -out privatekey_pkcs8.pem
+func ParseRSAPrivateKey(pemData []byte, passphrase []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+
+	der := block.Bytes
+	var err error
+
+	switch {
+	case block.Type == "ENCRYPTED PRIVATE KEY":
+		if len(passphrase) == 0 {
+			return nil, errors.New("private key is encrypted but no passphrase was provided")
+		}
+		der, err = decryptPKCS8(der, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		privateKey, err := parsePKCS8RSAPrivateKey(der)
+		if err != nil {
+			return nil, errDecryptPrivateKey
+		}
+		return privateKey, nil
+	case x509.IsEncryptedPEMBlock(block):
+		if len(passphrase) == 0 {
+			return nil, errors.New("private key is encrypted but no passphrase was provided")
+		}
+		der, err = x509.DecryptPEMBlock(block, passphrase)
+		if err != nil {
+			return nil, errDecryptPrivateKey
+		}
+		privateKey, err := parseUnencryptedRSAPrivateKey(block.Type, der)
+		if err != nil {
+			return nil, errDecryptPrivateKey
+		}
+		return privateKey, nil
+	}
+
+	return parseUnencryptedRSAPrivateKey(block.Type, der)
+}
+
+//parseUnencryptedRSAPrivateKey parses the DER of a PKCS#1 or PKCS#8 key with the given PEM block type
+func parseUnencryptedRSAPrivateKey(blockType string, der []byte) (*rsa.PrivateKey, error) {
+	switch blockType {
+	case "RSA PRIVATE KEY":
+		privateKey, err := x509.ParsePKCS1PrivateKey(der)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse PKCS#1 private key: %s", err.Error())
+		}
+		return privateKey, nil
+	case "PRIVATE KEY":
+		return parsePKCS8RSAPrivateKey(der)
+	default:
+		return nil, fmt.Errorf("unsupported private key type %q", blockType)
+	}
+}
+
+func parsePKCS8RSAPrivateKey(der []byte) (*rsa.PrivateKey, error) {
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse PKCS#8 private key: %s", err.Error())
+	}
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return privateKey, nil
+}
+
+var (
+	oidPBES2      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidAES128CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3CBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+)
+
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+//decryptPKCS8 decrypts a PKCS#8 EncryptedPrivateKeyInfo using PBES2 with PBKDF2,
+//which is what OpenSSL produces when a key is encrypted with a passphrase
+func decryptPKCS8(der []byte, passphrase []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("could not parse encrypted private key: %s", err.Error())
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, errors.New("encrypted private key must use PBES2")
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("could not parse PBES2 parameters: %s", err.Error())
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, errors.New("encrypted private key must use PBKDF2")
+	}
+
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, fmt.Errorf("could not parse PBKDF2 parameters: %s", err.Error())
+	}
+
+	prf := sha1.New
+	switch {
+	case kdf.PRF.Algorithm == nil, kdf.PRF.Algorithm.Equal(oidHMACSHA1):
+	case kdf.PRF.Algorithm.Equal(oidHMACSHA256):
+		prf = sha256.New
+	default:
+		return nil, fmt.Errorf("unsupported PBKDF2 PRF %s", kdf.PRF.Algorithm)
+	}
+
+	var keyLength int
+	var newBlock func(key []byte) (cipher.Block, error)
+	switch scheme := params.EncryptionScheme.Algorithm; {
+	case scheme.Equal(oidAES128CBC):
+		keyLength, newBlock = 16, aes.NewCipher
+	case scheme.Equal(oidAES192CBC):
+		keyLength, newBlock = 24, aes.NewCipher
+	case scheme.Equal(oidAES256CBC):
+		keyLength, newBlock = 32, aes.NewCipher
+	case scheme.Equal(oidDESEDE3CBC):
+		keyLength, newBlock = 24, des.NewTripleDESCipher
+	default:
+		return nil, fmt.Errorf("unsupported encryption scheme %s", scheme)
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("could not parse encryption parameters: %s", err.Error())
+	}
+
+	key := pbkdf2.Key(passphrase, kdf.Salt, kdf.IterationCount, keyLength, prf)
+	block, err := newBlock(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() || len(info.EncryptedData)%block.BlockSize() != 0 || len(info.EncryptedData) == 0 {
+		return nil, errors.New("encrypted private key is malformed")
+	}
+
+	decrypted := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(decrypted, info.EncryptedData)
+
+	//A wrong passphrase almost always shows up as invalid padding
+	padding, ok := pkcs7Padding(decrypted, block.BlockSize())
+	if !ok {
+		return nil, errDecryptPrivateKey
+	}
+	return decrypted[:len(decrypted)-padding], nil
+}
+
+//pkcs7Padding returns the length of the PKCS#7 padding at the end of data and whether it is valid.
+//The whole final block is always checked so the time taken doesn't depend on where the padding is wrong
+func pkcs7Padding(data []byte, blockSize int) (int, bool) {
+	padding := int(data[len(data)-1])
+	valid := subtle.ConstantTimeLessOrEq(1, padding) & subtle.ConstantTimeLessOrEq(padding, blockSize)
+	for n := 1; n <= blockSize; n++ {
+		inPadding := subtle.ConstantTimeLessOrEq(n, padding)
+		matches := subtle.ConstantTimeByteEq(data[len(data)-n], byte(padding))
+		valid &= matches | (inPadding ^ 1)
+	}
+	return padding, valid == 1
+}
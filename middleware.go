@@ -0,0 +1,80 @@
+package xerogolang
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+//RoundTripFunc sends a single request to the Xero API and returns the response
+type RoundTripFunc func(request *http.Request) (*http.Response, error)
+
+//Middleware wraps the sending of a request so that it can be inspected or changed on the way out
+//and the response inspected or replaced on the way back. endpoint is the path relative to the API root
+//that was passed to Find, Create, Update or Remove e.g. "Invoices/INV-001".
+//Middleware runs on every attempt, so requests that are retried will pass through it more than once
+type Middleware func(endpoint string, next RoundTripFunc) RoundTripFunc
+
+//ResponseInfo describes the outcome of a request for use by OnResponse hooks
+type ResponseInfo struct {
+	//Endpoint is the path relative to the API root e.g. "Invoices/INV-001"
+	Endpoint string
+
+	//Resource is the first segment of the Endpoint e.g. "Invoices", which is more useful for metrics
+	Resource string
+
+	//Method is the HTTP method of the request
+	Method string
+
+	//StatusCode is the HTTP status of the response, or 0 if no response was received
+	StatusCode int
+
+	//Latency is how long the request took, including any middleware further down the chain
+	Latency time.Duration
+
+	//Err is the error returned when no response was received
+	Err error
+}
+
+//OnRequest returns Middleware that calls hook before each request is sent.
+//hook can add headers to the request, e.g. a request ID or tracing header
+func OnRequest(hook func(endpoint string, request *http.Request)) Middleware {
+	return func(endpoint string, next RoundTripFunc) RoundTripFunc {
+		return func(request *http.Request) (*http.Response, error) {
+			hook(endpoint, request)
+			return next(request)
+		}
+	}
+}
+
+//OnResponse returns Middleware that calls hook after each request has completed
+func OnResponse(hook func(info ResponseInfo)) Middleware {
+	return func(endpoint string, next RoundTripFunc) RoundTripFunc {
+		return func(request *http.Request) (*http.Response, error) {
+			start := time.Now()
+			response, err := next(request)
+
+			info := ResponseInfo{
+				Endpoint: endpoint,
+				Resource: strings.SplitN(endpoint, "/", 2)[0],
+				Method:   request.Method,
+				Latency:  time.Since(start),
+				Err:      err,
+			}
+			if response != nil {
+				info.StatusCode = response.StatusCode
+			}
+			hook(info)
+
+			return response, err
+		}
+	}
+}
+
+//chain wraps roundTrip in the provider's middleware - the first Middleware is the outermost
+func (p *Provider) chain(endpoint string, roundTrip RoundTripFunc) RoundTripFunc {
+	for n := len(p.Middleware) - 1; n >= 0; n-- {
+		roundTrip = p.Middleware[n](endpoint, roundTrip)
+	}
+	return roundTrip
+}
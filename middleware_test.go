@@ -0,0 +1,86 @@
+package xerogolang
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrjones/oauth"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Middleware(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockProvider(ts)
+		provider.RetryPolicy = &RetryPolicy{MaxRetries: 1, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+
+		var order []string
+		var responses []ResponseInfo
+		faultInjected := false
+
+		provider.Middleware = []Middleware{
+			OnRequest(func(endpoint string, request *http.Request) {
+				order = append(order, "request:"+endpoint)
+				request.Header.Set("X-Request-Id", "abc-123")
+			}),
+			OnResponse(func(info ResponseInfo) {
+				order = append(order, "response")
+				responses = append(responses, info)
+			}),
+			func(endpoint string, next RoundTripFunc) RoundTripFunc {
+				return func(request *http.Request) (*http.Response, error) {
+					a.Equal("abc-123", request.Header.Get("X-Request-Id"))
+					if !faultInjected {
+						faultInjected = true
+						return &http.Response{
+							StatusCode: http.StatusBadGateway,
+							Header:     http.Header{},
+							Body:       ioutil.NopCloser(strings.NewReader("injected")),
+						}, nil
+					}
+					return next(request)
+				}
+			},
+		}
+
+		additionalHeaders := map[string]string{
+			"Accept": "application/json",
+		}
+
+		_, err := provider.Find(&session, "TrackingCategories", additionalHeaders, nil)
+		a.NoError(err)
+
+		a.Equal([]string{"request:TrackingCategories", "response", "request:TrackingCategories", "response"}, order)
+		a.Len(responses, 2)
+		a.Equal(http.StatusBadGateway, responses[0].StatusCode)
+		a.Equal(http.StatusOK, responses[1].StatusCode)
+		a.Equal("TrackingCategories", responses[1].Resource)
+		a.Equal("GET", responses[1].Method)
+		a.True(responses[1].Latency > 0)
+	})
+}
+
+func Test_OnResponse_Resource(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var info ResponseInfo
+	roundTrip := OnResponse(func(i ResponseInfo) {
+		info = i
+	})("Invoices/INV-001", func(request *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	_, err := roundTrip(httptest.NewRequest("DELETE", "/api.xro/2.0/Invoices/INV-001", nil))
+	a.NoError(err)
+	a.Equal("Invoices/INV-001", info.Endpoint)
+	a.Equal("Invoices", info.Resource)
+	a.Equal("DELETE", info.Method)
+}
@@ -0,0 +1,257 @@
+package xerogolang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+//DefaultOAuth2Scopes are requested by an OAuth 2.0 Provider when no Scopes have been set.
+//offline_access is needed for Xero to issue a refresh token
+//See https://developer.xero.com/documentation/oauth2/scopes
+var DefaultOAuth2Scopes = []string{
+	"openid",
+	"profile",
+	"email",
+	"offline_access",
+	"accounting.transactions",
+	"accounting.contacts",
+	"accounting.settings",
+	"accounting.reports.read",
+	"accounting.journals.read",
+	"accounting.attachments",
+}
+
+//oauth2RefreshWindow is how long before it expires that an OAuth 2.0 access token will be refreshed
+const oauth2RefreshWindow = time.Minute
+
+//NewOAuth2 creates a new Xero provider which authenticates using the OAuth 2.0 authorization code flow.
+//clientID and secret can be found at developer.xero.com under My Apps.
+//If no scopes are supplied DefaultOAuth2Scopes will be requested.
+//To send the name of your application in the User-Agent header use NewProvider with WithMethod("oauth2") and WithUserAgent
+func NewOAuth2(clientID, secret, callbackURL string, scopes ...string) *Provider {
+	p := &Provider{
+		ClientKey:       clientID,
+		Secret:          secret,
+		CallbackURL:     callbackURL,
+		Method:          "oauth2",
+		Scopes:          scopes,
+		UserAgentString: userAgentFor("", clientID),
+		providerName:    "xero",
+	}
+	return p
+}
+
+//isOAuth2 reports whether the provider uses OAuth 2.0 rather than OAuth 1.0a
+func (p *Provider) isOAuth2() bool {
+	return p.Method == "oauth2"
+}
+
+func (p *Provider) oauth2Config() *oauth2.Config {
+	endpoints := p.endpoints()
+	scopes := p.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultOAuth2Scopes
+	}
+	return &oauth2.Config{
+		ClientID:     p.ClientKey,
+		ClientSecret: p.Secret,
+		RedirectURL:  p.CallbackURL,
+		Scopes:       scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  endpoints.OAuth2AuthorizeURL,
+			TokenURL: endpoints.OAuth2TokenURL,
+		},
+	}
+}
+
+//oauth2Context makes the token endpoints use the provider's HTTPClient if one has been set
+func (p *Provider) oauth2Context(ctx context.Context) context.Context {
+	if p.HTTPClient != nil {
+		return context.WithValue(ctx, oauth2.HTTPClient, p.HTTPClient)
+	}
+	return ctx
+}
+
+//beginOAuth2 returns a session containing the URL the user should be sent to in order to grant access
+func (p *Provider) beginOAuth2(state string) *Session {
+	return &Session{
+		AuthURL: p.oauth2Config().AuthCodeURL(state),
+	}
+}
+
+//authorizeOAuth2 exchanges the code Xero sent to the callback URL for an access token and refresh token
+func (p *Provider) authorizeOAuth2(ctx context.Context, session *Session, code string) (string, error) {
+	if code == "" {
+		return "", errors.New("Missing authorization code")
+	}
+	token, err := p.oauth2Config().Exchange(p.oauth2Context(ctx), code)
+	if err != nil {
+		return "", err
+	}
+	session.setOAuth2Token(token)
+	return token.AccessToken, nil
+}
+
+//RefreshToken gets a new OAuth 2.0 access token using a refresh token.
+//Refresh tokens are only available to OAuth 2.0 providers - OAuth 1.0a Partner Applications must use RefreshOAuth1Token instead
+func (p *Provider) RefreshToken(refreshToken string) (*oauth2.Token, error) {
+	return p.RefreshTokenContext(context.Background(), refreshToken)
+}
+
+//RefreshTokenContext is like RefreshToken but uses ctx for the request so that it can be cancelled or given a deadline
+func (p *Provider) RefreshTokenContext(ctx context.Context, refreshToken string) (*oauth2.Token, error) {
+	if !p.isOAuth2() {
+		return nil, errors.New("Refresh token is only provided by Xero for OAuth 2.0 and Partner Applications - use RefreshOAuth1Token for Partner Applications")
+	}
+	if refreshToken == "" {
+		return nil, errors.New("Could not refresh token as no refresh token was found")
+	}
+	//An empty access token is never valid so the token source will always go to the token endpoint
+	tokenSource := p.oauth2Config().TokenSource(p.oauth2Context(ctx), &oauth2.Token{RefreshToken: refreshToken})
+	return tokenSource.Token()
+}
+
+//RefreshTokenAvailable reports whether the provider can refresh tokens with RefreshToken, which is only the case for OAuth 2.0
+func (p *Provider) RefreshTokenAvailable() bool {
+	return p.isOAuth2()
+}
+
+//RefreshOAuth2Token replaces the access token and refresh token held by an OAuth 2.0 session.
+//Xero rotates refresh tokens so the session must be stored again afterwards - OnTokenRefresh is called when it should be.
+//If the session is already being refreshed by another request this waits for that refresh instead of starting another
+func (p *Provider) RefreshOAuth2Token(ctx context.Context, session *Session) error {
+	_, err := p.oauth2AccessToken(ctx, session, true)
+	return err
+}
+
+//ensureOAuth2Token returns the session's access token, refreshing it first when it is about to expire
+func (p *Provider) ensureOAuth2Token(ctx context.Context, session *Session) (string, error) {
+	return p.oauth2AccessToken(ctx, session, false)
+}
+
+//tokenRefresh is a refresh of a session's tokens that is in progress. done is closed once err has been set
+type tokenRefresh struct {
+	done chan struct{}
+	err  error
+}
+
+//oauth2AccessToken returns the access token to send with a request on the session, refreshing the tokens first
+//if force is set or the access token is about to expire.
+//Xero rotates refresh tokens and each one can only be used once, so a session is only refreshed by one request
+//at a time. Other requests on it wait for that refresh to finish and then use the new access token
+func (p *Provider) oauth2AccessToken(ctx context.Context, session *Session, force bool) (string, error) {
+	for {
+		p.tokenMutex.Lock()
+		if refresh, ok := p.tokenRefreshes[session]; ok {
+			p.tokenMutex.Unlock()
+			select {
+			case <-refresh.done:
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+			if refresh.err != nil {
+				return "", refresh.err
+			}
+			force = false
+			continue
+		}
+
+		if !force {
+			accessToken, refresh := session.OAuth2AccessToken, session.needsOAuth2Refresh()
+			if !refresh {
+				p.tokenMutex.Unlock()
+				if accessToken == "" {
+					return "", fmt.Errorf("%s cannot process request without accessToken", p.providerName)
+				}
+				return accessToken, nil
+			}
+		}
+
+		refresh := &tokenRefresh{done: make(chan struct{})}
+		if p.tokenRefreshes == nil {
+			p.tokenRefreshes = map[*Session]*tokenRefresh{}
+		}
+		p.tokenRefreshes[session] = refresh
+		refreshToken := session.RefreshToken
+		p.tokenMutex.Unlock()
+
+		return p.refreshOAuth2Token(ctx, session, refreshToken, refresh)
+	}
+}
+
+//refreshOAuth2Token uses refreshToken to get new tokens for the session, then reports the refresh to
+//OnTokenRefresh and to any requests waiting for it
+func (p *Provider) refreshOAuth2Token(ctx context.Context, session *Session, refreshToken string, refresh *tokenRefresh) (accessToken string, err error) {
+	defer func() {
+		p.tokenMutex.Lock()
+		delete(p.tokenRefreshes, session)
+		p.tokenMutex.Unlock()
+		refresh.err = err
+		close(refresh.done)
+	}()
+
+	token, err := p.RefreshTokenContext(ctx, refreshToken)
+	if err != nil {
+		return "", err
+	}
+
+	p.tokenMutex.Lock()
+	session.setOAuth2Token(token)
+	p.tokenMutex.Unlock()
+
+	if p.OnTokenRefresh != nil {
+		err = p.OnTokenRefresh(session)
+		if err != nil {
+			return "", err
+		}
+	}
+	return token.AccessToken, nil
+}
+
+//RevokeToken revokes the refresh token held by an OAuth 2.0 session, disconnecting it from Xero.
+//The session's tokens are cleared if the revocation succeeds
+func (p *Provider) RevokeToken(session *Session) error {
+	return p.RevokeTokenContext(context.Background(), session)
+}
+
+//RevokeTokenContext is like RevokeToken but uses ctx for the request so that it can be cancelled or given a deadline
+func (p *Provider) RevokeTokenContext(ctx context.Context, session *Session) error {
+	if !p.isOAuth2() {
+		return errors.New("Only OAuth 2.0 tokens can be revoked")
+	}
+	if session.RefreshToken == "" {
+		return errors.New("Could not revoke token as no refresh token was found")
+	}
+
+	form := url.Values{
+		"token": {session.RefreshToken},
+	}
+	request, err := http.NewRequestWithContext(ctx, "POST", p.endpoints().OAuth2RevocationURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.SetBasicAuth(url.QueryEscape(p.ClientKey), url.QueryEscape(p.Secret))
+
+	response, err := p.Client().Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return newAPIError(response)
+	}
+
+	session.OAuth2AccessToken = ""
+	session.RefreshToken = ""
+	session.AccessTokenExpires = time.Time{}
+	return nil
+}
@@ -0,0 +1,257 @@
+package xerogolang
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func xeroOAuth2Provider() *Provider {
+	return NewOAuth2("CLIENT", "SECRET", "http://localhost/callback", "offline_access", "accounting.transactions")
+}
+
+//mockOAuth2Provider is an OAuth 2.0 provider that talks to the server started by mockXero
+func mockOAuth2Provider(ts *httptest.Server) *Provider {
+	provider := xeroOAuth2Provider()
+	provider.Endpoints = EndpointsForBaseURL(ts.URL)
+	return provider
+}
+
+func Test_NewOAuth2_UserAgent(t *testing.T) {
+	a := assert.New(t)
+
+	t.Setenv("XERO_USER_AGENT", "Env App")
+	a.Equal(" (xerogolang 0.1.3) CLIENT", xeroOAuth2Provider().UserAgentString, "the environment should not be read")
+
+	provider, err := NewProvider(
+		WithCredentials("CLIENT", "SECRET"),
+		WithMethod("oauth2"),
+		WithCallbackURL("http://localhost/callback"),
+		WithUserAgent("My App"),
+	)
+	a.NoError(err)
+	a.Equal("My App (xerogolang 0.1.3) CLIENT", provider.UserAgentString)
+}
+
+func Test_OAuth2_BeginAuth(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockOAuth2Provider(ts)
+		a.True(provider.RefreshTokenAvailable())
+
+		session, err := provider.BeginAuth("STATE")
+		a.NoError(err)
+
+		authURL, err := url.Parse(session.(*Session).AuthURL)
+		a.NoError(err)
+		a.Equal("/identity/connect/authorize", authURL.Path)
+		a.Equal("CLIENT", authURL.Query().Get("client_id"))
+		a.Equal("STATE", authURL.Query().Get("state"))
+		a.Equal("code", authURL.Query().Get("response_type"))
+		a.Equal("offline_access accounting.transactions", authURL.Query().Get("scope"))
+		a.Equal("http://localhost/callback", authURL.Query().Get("redirect_uri"))
+	})
+}
+
+func Test_OAuth2_Authorize(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockOAuth2Provider(ts)
+		session := &Session{}
+
+		token, err := session.Authorize(provider, url.Values{"code": {"CODE"}})
+		a.NoError(err)
+		a.Equal("ACCESS", token)
+		a.Equal("ACCESS", session.OAuth2AccessToken)
+		a.Equal("REFRESH", session.RefreshToken)
+		a.Equal("tenant-1", session.TenantID)
+		a.WithinDuration(time.Now().UTC().Add(30*time.Minute), session.AccessTokenExpires, time.Minute)
+
+		restored, err := provider.UnmarshalSession(session.Marshal())
+		a.NoError(err)
+		a.Equal("REFRESH", restored.(*Session).RefreshToken)
+
+		_, err = (&Session{}).Authorize(provider, url.Values{"code": {"WRONG"}})
+		a.Error(err)
+	})
+}
+
+func Test_OAuth2_FindRefreshesExpiringToken(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockOAuth2Provider(ts)
+		session := &Session{
+			OAuth2AccessToken:  "ACCESS",
+			RefreshToken:       "REFRESH",
+			AccessTokenExpires: time.Now().UTC().Add(10 * time.Second),
+		}
+
+		response, err := provider.Find(session, "Bearer", map[string]string{"Accept": "application/json"}, nil)
+		a.NoError(err)
+
+		var testResponse *Tests
+		err = json.Unmarshal(response, &testResponse)
+		a.NoError(err)
+		a.Equal("Bearer REFRESHED", testResponse.Tests[0].Name)
+		a.Equal("REFRESH2", session.RefreshToken)
+		a.True(session.AccessTokenExpires.After(time.Now().UTC().Add(20 * time.Minute)))
+	})
+}
+
+func Test_OAuth2_ConcurrentRequestsRefreshOnce(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	//like Xero's, this token endpoint only accepts each refresh token once
+	var tokenMutex sync.Mutex
+	refreshes := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		tokenMutex.Lock()
+		defer tokenMutex.Unlock()
+
+		res.Header().Set("Content-Type", "application/json")
+		if req.FormValue("refresh_token") != "REFRESH" || refreshes > 0 {
+			res.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(res, `{"error":"invalid_grant"}`)
+			return
+		}
+		refreshes++
+		fmt.Fprint(res, `{"access_token":"REFRESHED","refresh_token":"REFRESH2","expires_in":1800,"token_type":"Bearer"}`)
+	}))
+	defer tokenServer.Close()
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockOAuth2Provider(ts)
+		provider.Endpoints.OAuth2TokenURL = tokenServer.URL
+
+		var stored []string
+		provider.OnTokenRefresh = func(session *Session) error {
+			stored = append(stored, session.RefreshToken)
+			return nil
+		}
+
+		session := &Session{
+			OAuth2AccessToken:  "ACCESS",
+			RefreshToken:       "REFRESH",
+			AccessTokenExpires: time.Now().UTC().Add(10 * time.Second),
+		}
+
+		var wg sync.WaitGroup
+		names := make([]string, 20)
+		errs := make([]error, len(names))
+		for i := range names {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				response, err := provider.Find(session, "Bearer", map[string]string{"Accept": "application/json"}, nil)
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				var testResponse *Tests
+				errs[i] = json.Unmarshal(response, &testResponse)
+				if errs[i] == nil {
+					names[i] = testResponse.Tests[0].Name
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		for i := range names {
+			a.NoError(errs[i])
+			a.Equal("Bearer REFRESHED", names[i])
+		}
+		a.Equal(1, refreshes)
+		a.Equal([]string{"REFRESH2"}, stored)
+		a.Equal("REFRESH2", session.RefreshToken)
+
+		//refreshing again uses the rotated refresh token, which this endpoint has not seen
+		err := provider.RefreshOAuth2Token(context.Background(), session)
+		a.Error(err)
+		a.Equal([]string{"REFRESH2"}, stored)
+	})
+}
+
+func Test_OAuth2_OnTokenRefreshError(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider, err := NewProvider(
+			WithCredentials("CLIENT", "SECRET"),
+			WithMethod("oauth2"),
+			WithCallbackURL("http://localhost/callback"),
+			WithBaseURL(ts.URL),
+			WithOnTokenRefresh(func(session *Session) error {
+				return errors.New("store unavailable")
+			}),
+		)
+		a.NoError(err)
+
+		session := &Session{
+			OAuth2AccessToken:  "ACCESS",
+			RefreshToken:       "REFRESH",
+			AccessTokenExpires: time.Now().UTC().Add(10 * time.Second),
+		}
+		_, err = provider.Find(session, "Bearer", map[string]string{"Accept": "application/json"}, nil)
+		a.EqualError(err, "store unavailable")
+		a.Equal("REFRESH2", session.RefreshToken)
+	})
+}
+
+func Test_OAuth2_RefreshToken(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockOAuth2Provider(ts)
+
+		token, err := provider.RefreshToken("REFRESH")
+		a.NoError(err)
+		a.Equal("REFRESHED", token.AccessToken)
+		a.Equal("REFRESH2", token.RefreshToken)
+
+		_, err = provider.RefreshToken("")
+		a.Error(err)
+
+		_, err = xeroProvider().RefreshToken("REFRESH")
+		a.Error(err)
+	})
+}
+
+func Test_OAuth2_RevokeToken(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockOAuth2Provider(ts)
+		session := &Session{
+			OAuth2AccessToken:  "ACCESS",
+			RefreshToken:       "REFRESH",
+			AccessTokenExpires: time.Now().UTC().Add(30 * time.Minute),
+		}
+
+		err := provider.RevokeToken(session)
+		a.NoError(err)
+		a.Equal("", session.RefreshToken)
+		a.Equal("", session.OAuth2AccessToken)
+
+		err = provider.RevokeToken(session)
+		a.Error(err)
+	})
+}
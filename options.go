@@ -0,0 +1,260 @@
+package xerogolang
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/XeroAPI/xerogolang/helpers"
+)
+
+//Logger is the interface a Provider logs through. *log.Logger satisfies it
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+//logf writes to the provider's Logger if it has one
+func (p *Provider) logf(format string, v ...interface{}) {
+	if p.Logger != nil {
+		p.Logger.Printf("xerogolang: "+format, v...)
+	}
+}
+
+//Option configures a Provider created by NewProvider
+type Option func(*providerSettings) error
+
+//providerSettings collects the options passed to NewProvider before they are applied to the Provider
+type providerSettings struct {
+	provider       *Provider
+	appName        string
+	privateKeyPath string
+}
+
+//NewProvider creates a new Xero provider configured by the given options.
+//Unlike New it never reads environment variables unless WithEnvironment is used.
+//An error is returned if the options are inconsistent, e.g. a private key for a public application,
+//or if the private key can't be read
+func NewProvider(options ...Option) (*Provider, error) {
+	settings := &providerSettings{
+		provider: &Provider{
+			providerName: "xero",
+		},
+	}
+
+	for _, option := range options {
+		err := option(settings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p := settings.provider
+	if settings.privateKeyPath != "" {
+		privateKey, err := helpers.LoadPrivateKeyFromPath(settings.privateKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		p.PrivateKey = privateKey
+	}
+	p.UserAgentString = userAgentFor(settings.appName, p.ClientKey)
+
+	err := settings.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+//validate checks for options that don't make sense together
+func (s *providerSettings) validate() error {
+	p := s.provider
+	if p.ClientKey == "" {
+		return errors.New("a client key is required - use WithCredentials")
+	}
+
+	switch p.Method {
+	case "private", "partner":
+		if p.PrivateKey == "" {
+			return fmt.Errorf("a private key is required for %s applications - use WithPrivateKey or WithPrivateKeyFile", p.Method)
+		}
+	default:
+		if p.PrivateKey != "" {
+			return fmt.Errorf("a private key is only used by private and partner applications, not %q", p.Method)
+		}
+	}
+
+	if len(p.Scopes) > 0 && !p.isOAuth2() {
+		return errors.New("scopes are only used by OAuth 2.0 - use WithMethod(\"oauth2\")")
+	}
+	if p.isOAuth2() && p.CallbackURL == "" {
+		return errors.New("a callback URL is required for OAuth 2.0 - use WithCallbackURL")
+	}
+
+	return p.Validate()
+}
+
+//WithCredentials sets the consumer key and secret, or the client id and secret for OAuth 2.0
+func WithCredentials(clientKey, secret string) Option {
+	return func(s *providerSettings) error {
+		s.provider.ClientKey = clientKey
+		s.provider.Secret = secret
+		return nil
+	}
+}
+
+//WithCallbackURL sets the URL Xero redirects the user back to after they have granted access
+func WithCallbackURL(callbackURL string) Option {
+	return func(s *providerSettings) error {
+		s.provider.CallbackURL = callbackURL
+		return nil
+	}
+}
+
+//WithMethod sets how the provider connects to Xero - public, private, partner or oauth2
+func WithMethod(method string) Option {
+	return func(s *providerSettings) error {
+		switch method {
+		case "public", "private", "partner", "oauth2":
+			s.provider.Method = method
+			return nil
+		}
+		return fmt.Errorf("unknown method %q - use public, private, partner or oauth2", method)
+	}
+}
+
+//WithScopes sets the scopes requested by an OAuth 2.0 provider
+func WithScopes(scopes ...string) Option {
+	return func(s *providerSettings) error {
+		s.provider.Scopes = scopes
+		return nil
+	}
+}
+
+//WithHTTPClient sets the http.Client used to talk to Xero
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(s *providerSettings) error {
+		if httpClient == nil {
+			return errors.New("WithHTTPClient requires a client")
+		}
+		s.provider.HTTPClient = httpClient
+		return nil
+	}
+}
+
+//WithPrivateKey sets the PEM encoded private key of a private or partner application.
+//passphrase is only needed if the key is encrypted
+func WithPrivateKey(privateKey []byte, passphrase []byte) Option {
+	return func(s *providerSettings) error {
+		s.provider.PrivateKey = string(privateKey)
+		s.provider.PrivateKeyPassphrase = string(passphrase)
+		s.privateKeyPath = ""
+		return nil
+	}
+}
+
+//WithPrivateKeyFile reads the private key of a private or partner application from a .pem file.
+//passphrase is only needed if the key is encrypted
+func WithPrivateKeyFile(privateKeyPath string, passphrase []byte) Option {
+	return func(s *providerSettings) error {
+		s.privateKeyPath = privateKeyPath
+		s.provider.PrivateKeyPassphrase = string(passphrase)
+		return nil
+	}
+}
+
+//WithUserAgent sets the name of your application, which is sent to Xero in the User-Agent header
+func WithUserAgent(appName string) Option {
+	return func(s *providerSettings) error {
+		s.appName = appName
+		return nil
+	}
+}
+
+//WithBaseURL points every endpoint of the provider at baseURL - see EndpointsForBaseURL
+func WithBaseURL(baseURL string) Option {
+	return func(s *providerSettings) error {
+		if baseURL == "" {
+			return errors.New("WithBaseURL requires a URL")
+		}
+		s.provider.Endpoints = EndpointsForBaseURL(baseURL)
+		return nil
+	}
+}
+
+//WithEndpoints sets the endpoints of the provider. Any left empty use DefaultEndpoints
+func WithEndpoints(endpoints Endpoints) Option {
+	return func(s *providerSettings) error {
+		s.provider.Endpoints = endpoints
+		return nil
+	}
+}
+
+//WithRetryPolicy sets how rate limited and failed requests are retried
+func WithRetryPolicy(retryPolicy *RetryPolicy) Option {
+	return func(s *providerSettings) error {
+		s.provider.RetryPolicy = retryPolicy
+		return nil
+	}
+}
+
+//WithMiddleware adds middleware to the provider, after any that have already been added
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(s *providerSettings) error {
+		s.provider.Middleware = append(s.provider.Middleware, middleware...)
+		return nil
+	}
+}
+
+//WithLogger sets the Logger that failed and retried requests are logged to
+func WithLogger(logger Logger) Option {
+	return func(s *providerSettings) error {
+		s.provider.Logger = logger
+		return nil
+	}
+}
+
+//WithOnTokenRefresh sets the function called after an OAuth 2.0 session's tokens have been refreshed.
+//Use it to store the session again, as Xero only accepts each refresh token once
+func WithOnTokenRefresh(fn func(session *Session) error) Option {
+	return func(s *providerSettings) error {
+		s.provider.OnTokenRefresh = fn
+		return nil
+	}
+}
+
+//WithName sets the name of the provider, for when more than one Xero provider is used with goth
+func WithName(name string) Option {
+	return func(s *providerSettings) error {
+		s.provider.providerName = name
+		return nil
+	}
+}
+
+//WithEnvironment reads the configuration from the environment variables used by New:
+//XERO_KEY, XERO_SECRET, XERO_METHOD, XERO_USER_AGENT and XERO_PRIVATE_KEY_PATH.
+//Only variables that are set are used, and options after it override them
+func WithEnvironment() Option {
+	return func(s *providerSettings) error {
+		if key := os.Getenv("XERO_KEY"); key != "" {
+			s.provider.ClientKey = key
+		}
+		if secret := os.Getenv("XERO_SECRET"); secret != "" {
+			s.provider.Secret = secret
+		}
+		if method := os.Getenv("XERO_METHOD"); method != "" {
+			err := WithMethod(method)(s)
+			if err != nil {
+				return fmt.Errorf("XERO_METHOD: %s", err.Error())
+			}
+		}
+		if appName := os.Getenv("XERO_USER_AGENT"); appName != "" {
+			s.appName = appName
+		}
+		if privateKeyPath := os.Getenv("XERO_PRIVATE_KEY_PATH"); privateKeyPath != "" {
+			s.privateKeyPath = privateKeyPath
+		}
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+package xerogolang
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mrjones/oauth"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewProvider(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	httpClient := &http.Client{Timeout: time.Second}
+	provider, err := NewProvider(
+		WithCredentials("KEY", "SECRET"),
+		WithMethod("private"),
+		WithPrivateKeyFile("testdata/privatekey_pkcs1_encrypted.pem", []byte("secret")),
+		WithUserAgent("My App"),
+		WithHTTPClient(httpClient),
+		WithBaseURL("http://localhost:8080"),
+	)
+	a.NoError(err)
+	a.Equal("KEY", provider.ClientKey)
+	a.Equal("private", provider.Method)
+	a.Equal("My App (xerogolang 0.1.3) KEY", provider.UserAgentString)
+	a.Equal(httpClient, provider.HTTPClient)
+	a.Equal("http://localhost:8080/api.xro/2.0/", provider.Endpoints.APIRoot(AccountingAPI))
+	a.Equal("xero", provider.Name())
+}
+
+func Test_NewProvider_Inconsistent(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	privateKey, err := ioutil.ReadFile("testdata/privatekey_pkcs1.pem")
+	a.NoError(err)
+
+	_, err = NewProvider(WithMethod("public"))
+	a.EqualError(err, "a client key is required - use WithCredentials")
+
+	_, err = NewProvider(WithCredentials("KEY", "SECRET"), WithMethod("magic"))
+	a.Error(err)
+
+	_, err = NewProvider(WithCredentials("KEY", "SECRET"), WithMethod("private"))
+	a.Error(err)
+
+	_, err = NewProvider(WithCredentials("KEY", "SECRET"), WithMethod("public"), WithPrivateKey(privateKey, nil))
+	a.Error(err)
+
+	_, err = NewProvider(WithCredentials("KEY", "SECRET"), WithMethod("private"), WithPrivateKey([]byte("not a key"), nil))
+	a.Error(err)
+
+	_, err = NewProvider(WithCredentials("KEY", "SECRET"), WithMethod("private"), WithPrivateKeyFile("testdata/missing.pem", nil))
+	a.Error(err)
+
+	_, err = NewProvider(WithCredentials("KEY", "SECRET"), WithMethod("public"), WithScopes("accounting.transactions"))
+	a.Error(err)
+
+	_, err = NewProvider(WithCredentials("CLIENT", "SECRET"), WithMethod("oauth2"))
+	a.EqualError(err, "a callback URL is required for OAuth 2.0 - use WithCallbackURL")
+
+	_, err = NewProvider(WithCredentials("CLIENT", "SECRET"), WithMethod("oauth2"), WithCallbackURL("http://localhost/callback"), WithScopes("accounting.transactions"))
+	a.NoError(err)
+}
+
+func Test_NewProvider_Environment(t *testing.T) {
+	a := assert.New(t)
+
+	t.Setenv("XERO_KEY", "ENVKEY")
+	t.Setenv("XERO_SECRET", "ENVSECRET")
+	t.Setenv("XERO_METHOD", "public")
+	t.Setenv("XERO_USER_AGENT", "Env App")
+
+	_, err := NewProvider(WithMethod("public"))
+	a.Error(err, "the environment should only be read when asked")
+
+	provider, err := NewProvider(WithEnvironment(), WithCredentials("KEY", "SECRET"))
+	a.NoError(err)
+	a.Equal("KEY", provider.ClientKey)
+	a.Equal("public", provider.Method)
+	a.Equal("Env App (xerogolang 0.1.3) KEY", provider.UserAgentString)
+}
+
+func Test_NewProvider_Logger(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		var logged bytes.Buffer
+		provider, err := NewProvider(
+			WithCredentials("KEY", "SECRET"),
+			WithMethod("public"),
+			WithBaseURL(ts.URL),
+			WithRetryPolicy(&RetryPolicy{MaxRetries: 0}),
+			WithLogger(log.New(&logged, "", 0)),
+		)
+		a.NoError(err)
+		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+
+		_, err = provider.Find(&session, "Missing", map[string]string{"Accept": "application/json"}, nil)
+		a.Error(err)
+		a.Contains(logged.String(), "xerogolang: GET Missing failed: xero: 404")
+	})
+}
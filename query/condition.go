@@ -0,0 +1,72 @@
+package query
+
+import (
+	"strings"
+)
+
+//Condition is part of a where clause
+type Condition struct {
+	expression string
+	//compound is set for conditions joined with AND or OR so they are bracketed when combined further
+	compound bool
+}
+
+//Raw is a condition written by hand, for anything the typed fields can't express
+func Raw(expression string) Condition {
+	return Condition{expression: expression, compound: true}
+}
+
+//String returns the condition as Xero expects it in the where parameter
+func (c Condition) String() string {
+	return c.expression
+}
+
+//IsEmpty reports whether the condition has no expression, like the zero Condition
+func (c Condition) IsEmpty() bool {
+	return c.expression == ""
+}
+
+//And matches when every one of the conditions does. Empty conditions are ignored
+func And(conditions ...Condition) Condition {
+	return join(" AND ", conditions)
+}
+
+//Or matches when any one of the conditions does. Empty conditions are ignored
+func Or(conditions ...Condition) Condition {
+	return join(" OR ", conditions)
+}
+
+//And matches when both c and other do
+func (c Condition) And(other Condition) Condition {
+	return And(c, other)
+}
+
+//Or matches when either c or other does
+func (c Condition) Or(other Condition) Condition {
+	return Or(c, other)
+}
+
+func join(operator string, conditions []Condition) Condition {
+	var nonEmpty []Condition
+	for _, condition := range conditions {
+		if !condition.IsEmpty() {
+			nonEmpty = append(nonEmpty, condition)
+		}
+	}
+
+	switch len(nonEmpty) {
+	case 0:
+		return Condition{}
+	case 1:
+		return nonEmpty[0]
+	}
+
+	expressions := make([]string, len(nonEmpty))
+	for n, condition := range nonEmpty {
+		expressions[n] = condition.expression
+		if condition.compound {
+			expressions[n] = "(" + condition.expression + ")"
+		}
+	}
+	return Condition{expression: strings.Join(expressions, operator), compound: true}
+}
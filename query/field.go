@@ -0,0 +1,183 @@
+package query
+
+import (
+	"time"
+)
+
+//Field is anything the results can be ordered by
+type Field interface {
+	Name() string
+}
+
+//compare builds a condition comparing a field to a literal
+func compare(name string, operator string, literal string) Condition {
+	return Condition{expression: name + operator + literal}
+}
+
+//StringField is a text field such as a Status or Reference
+type StringField string
+
+//Name returns the name of the field
+func (f StringField) Name() string {
+	return string(f)
+}
+
+//Equals matches when the field is value
+func (f StringField) Equals(value string) Condition {
+	return compare(f.Name(), "==", String(value))
+}
+
+//NotEquals matches when the field is not value
+func (f StringField) NotEquals(value string) Condition {
+	return compare(f.Name(), "!=", String(value))
+}
+
+//In matches when the field is any of values
+func (f StringField) In(values ...string) Condition {
+	conditions := make([]Condition, len(values))
+	for n, value := range values {
+		conditions[n] = f.Equals(value)
+	}
+	return Or(conditions...)
+}
+
+//Contains matches when the field contains value
+func (f StringField) Contains(value string) Condition {
+	return compare(f.Name(), ".Contains(", String(value)+")")
+}
+
+//StartsWith matches when the field starts with value
+func (f StringField) StartsWith(value string) Condition {
+	return compare(f.Name(), ".StartsWith(", String(value)+")")
+}
+
+//EndsWith matches when the field ends with value
+func (f StringField) EndsWith(value string) Condition {
+	return compare(f.Name(), ".EndsWith(", String(value)+")")
+}
+
+//IsNull matches when the field has no value
+func (f StringField) IsNull() Condition {
+	return compare(f.Name(), "==", "null")
+}
+
+//IsNotNull matches when the field has a value
+func (f StringField) IsNotNull() Condition {
+	return compare(f.Name(), "!=", "null")
+}
+
+//GuidField is an identifier such as a ContactID
+type GuidField string
+
+//Name returns the name of the field
+func (f GuidField) Name() string {
+	return string(f)
+}
+
+//Equals matches when the field is id
+func (f GuidField) Equals(id string) Condition {
+	return compare(f.Name(), "==", Guid(id))
+}
+
+//NotEquals matches when the field is not id
+func (f GuidField) NotEquals(id string) Condition {
+	return compare(f.Name(), "!=", Guid(id))
+}
+
+//In matches when the field is any of ids
+func (f GuidField) In(ids ...string) Condition {
+	conditions := make([]Condition, len(ids))
+	for n, id := range ids {
+		conditions[n] = f.Equals(id)
+	}
+	return Or(conditions...)
+}
+
+//DateField is a date or date and time such as a DueDate
+type DateField string
+
+//Name returns the name of the field
+func (f DateField) Name() string {
+	return string(f)
+}
+
+//Equals matches when the field is t
+func (f DateField) Equals(t time.Time) Condition {
+	return compare(f.Name(), "==", DateTime(t))
+}
+
+//Before matches when the field is earlier than t
+func (f DateField) Before(t time.Time) Condition {
+	return compare(f.Name(), "<", DateTime(t))
+}
+
+//OnOrBefore matches when the field is t or earlier
+func (f DateField) OnOrBefore(t time.Time) Condition {
+	return compare(f.Name(), "<=", DateTime(t))
+}
+
+//After matches when the field is later than t
+func (f DateField) After(t time.Time) Condition {
+	return compare(f.Name(), ">", DateTime(t))
+}
+
+//OnOrAfter matches when the field is t or later
+func (f DateField) OnOrAfter(t time.Time) Condition {
+	return compare(f.Name(), ">=", DateTime(t))
+}
+
+//Between matches when the field is from and to or between them
+func (f DateField) Between(from time.Time, to time.Time) Condition {
+	return And(f.OnOrAfter(from), f.OnOrBefore(to))
+}
+
+//NumberField is an amount or quantity such as a Total
+type NumberField string
+
+//Name returns the name of the field
+func (f NumberField) Name() string {
+	return string(f)
+}
+
+//Equals matches when the field is n
+func (f NumberField) Equals(n float64) Condition {
+	return compare(f.Name(), "==", Number(n))
+}
+
+//NotEquals matches when the field is not n
+func (f NumberField) NotEquals(n float64) Condition {
+	return compare(f.Name(), "!=", Number(n))
+}
+
+//LessThan matches when the field is less than n
+func (f NumberField) LessThan(n float64) Condition {
+	return compare(f.Name(), "<", Number(n))
+}
+
+//LessThanOrEqual matches when the field is n or less
+func (f NumberField) LessThanOrEqual(n float64) Condition {
+	return compare(f.Name(), "<=", Number(n))
+}
+
+//GreaterThan matches when the field is more than n
+func (f NumberField) GreaterThan(n float64) Condition {
+	return compare(f.Name(), ">", Number(n))
+}
+
+//GreaterThanOrEqual matches when the field is n or more
+func (f NumberField) GreaterThanOrEqual(n float64) Condition {
+	return compare(f.Name(), ">=", Number(n))
+}
+
+//BoolField is a flag such as IsSupplier
+type BoolField string
+
+//Name returns the name of the field
+func (f BoolField) Name() string {
+	return string(f)
+}
+
+//Is matches when the field is b
+func (f BoolField) Is(b bool) Condition {
+	return compare(f.Name(), "==", Bool(b))
+}
@@ -0,0 +1,239 @@
+package query
+
+//InvoiceFields are the fields of Invoices that can be filtered and ordered on
+type InvoiceFields struct {
+	InvoiceID      GuidField
+	Type           StringField
+	Status         StringField
+	InvoiceNumber  StringField
+	Reference      StringField
+	ContactID      GuidField
+	ContactName    StringField
+	Date           DateField
+	DueDate        DateField
+	UpdatedDateUTC DateField
+	CurrencyCode   StringField
+	Total          NumberField
+	AmountDue      NumberField
+	AmountPaid     NumberField
+	SentToContact  BoolField
+}
+
+//Invoice refers to the fields of Invoices e.g. query.Invoice.Type
+var Invoice = InvoiceFields{
+	InvoiceID:      "InvoiceID",
+	Type:           "Type",
+	Status:         "Status",
+	InvoiceNumber:  "InvoiceNumber",
+	Reference:      "Reference",
+	ContactID:      "Contact.ContactID",
+	ContactName:    "Contact.Name",
+	Date:           "Date",
+	DueDate:        "DueDate",
+	UpdatedDateUTC: "UpdatedDateUTC",
+	CurrencyCode:   "CurrencyCode",
+	Total:          "Total",
+	AmountDue:      "AmountDue",
+	AmountPaid:     "AmountPaid",
+	SentToContact:  "SentToContact",
+}
+
+//ContactFields are the fields of Contacts that can be filtered and ordered on
+type ContactFields struct {
+	ContactID      GuidField
+	ContactNumber  StringField
+	AccountNumber  StringField
+	ContactStatus  StringField
+	Name           StringField
+	FirstName      StringField
+	LastName       StringField
+	EmailAddress   StringField
+	TaxNumber      StringField
+	IsSupplier     BoolField
+	IsCustomer     BoolField
+	UpdatedDateUTC DateField
+}
+
+//Contact refers to the fields of Contacts e.g. query.Contact.ContactNumber
+var Contact = ContactFields{
+	ContactID:      "ContactID",
+	ContactNumber:  "ContactNumber",
+	AccountNumber:  "AccountNumber",
+	ContactStatus:  "ContactStatus",
+	Name:           "Name",
+	FirstName:      "FirstName",
+	LastName:       "LastName",
+	EmailAddress:   "EmailAddress",
+	TaxNumber:      "TaxNumber",
+	IsSupplier:     "IsSupplier",
+	IsCustomer:     "IsCustomer",
+	UpdatedDateUTC: "UpdatedDateUTC",
+}
+
+//BankTransactionFields are the fields of BankTransactions that can be filtered and ordered on
+type BankTransactionFields struct {
+	BankTransactionID GuidField
+	Type              StringField
+	Status            StringField
+	Reference         StringField
+	ContactID         GuidField
+	ContactName       StringField
+	BankAccountID     GuidField
+	BankAccountCode   StringField
+	Date              DateField
+	UpdatedDateUTC    DateField
+	CurrencyCode      StringField
+	Total             NumberField
+	IsReconciled      BoolField
+}
+
+//BankTransaction refers to the fields of BankTransactions e.g. query.BankTransaction.Type
+var BankTransaction = BankTransactionFields{
+	BankTransactionID: "BankTransactionID",
+	Type:              "Type",
+	Status:            "Status",
+	Reference:         "Reference",
+	ContactID:         "Contact.ContactID",
+	ContactName:       "Contact.Name",
+	BankAccountID:     "BankAccount.AccountID",
+	BankAccountCode:   "BankAccount.Code",
+	Date:              "Date",
+	UpdatedDateUTC:    "UpdatedDateUTC",
+	CurrencyCode:      "CurrencyCode",
+	Total:             "Total",
+	IsReconciled:      "IsReconciled",
+}
+
+//CreditNoteFields are the fields of CreditNotes that can be filtered and ordered on
+type CreditNoteFields struct {
+	CreditNoteID     GuidField
+	Type             StringField
+	Status           StringField
+	CreditNoteNumber StringField
+	Reference        StringField
+	ContactID        GuidField
+	ContactName      StringField
+	Date             DateField
+	UpdatedDateUTC   DateField
+	CurrencyCode     StringField
+	Total            NumberField
+	RemainingCredit  NumberField
+}
+
+//CreditNote refers to the fields of CreditNotes e.g. query.CreditNote.Type
+var CreditNote = CreditNoteFields{
+	CreditNoteID:     "CreditNoteID",
+	Type:             "Type",
+	Status:           "Status",
+	CreditNoteNumber: "CreditNoteNumber",
+	Reference:        "Reference",
+	ContactID:        "Contact.ContactID",
+	ContactName:      "Contact.Name",
+	Date:             "Date",
+	UpdatedDateUTC:   "UpdatedDateUTC",
+	CurrencyCode:     "CurrencyCode",
+	Total:            "Total",
+	RemainingCredit:  "RemainingCredit",
+}
+
+//PaymentFields are the fields of Payments that can be filtered and ordered on
+type PaymentFields struct {
+	PaymentID      GuidField
+	Status         StringField
+	PaymentType    StringField
+	Reference      StringField
+	InvoiceID      GuidField
+	AccountID      GuidField
+	Date           DateField
+	UpdatedDateUTC DateField
+	Amount         NumberField
+	IsReconciled   BoolField
+}
+
+//Payment refers to the fields of Payments e.g. query.Payment.Status
+var Payment = PaymentFields{
+	PaymentID:      "PaymentID",
+	Status:         "Status",
+	PaymentType:    "PaymentType",
+	Reference:      "Reference",
+	InvoiceID:      "Invoice.InvoiceID",
+	AccountID:      "Account.AccountID",
+	Date:           "Date",
+	UpdatedDateUTC: "UpdatedDateUTC",
+	Amount:         "Amount",
+	IsReconciled:   "IsReconciled",
+}
+
+//AccountFields are the fields of Accounts that can be filtered and ordered on
+type AccountFields struct {
+	AccountID               GuidField
+	Code                    StringField
+	Name                    StringField
+	Type                    StringField
+	Class                   StringField
+	Status                  StringField
+	TaxType                 StringField
+	EnablePaymentsToAccount BoolField
+	UpdatedDateUTC          DateField
+}
+
+//Account refers to the fields of Accounts e.g. query.Account.Code
+var Account = AccountFields{
+	AccountID:               "AccountID",
+	Code:                    "Code",
+	Name:                    "Name",
+	Type:                    "Type",
+	Class:                   "Class",
+	Status:                  "Status",
+	TaxType:                 "TaxType",
+	EnablePaymentsToAccount: "EnablePaymentsToAccount",
+	UpdatedDateUTC:          "UpdatedDateUTC",
+}
+
+//ItemFields are the fields of Items that can be filtered and ordered on
+type ItemFields struct {
+	ItemID               GuidField
+	Code                 StringField
+	Name                 StringField
+	IsSold               BoolField
+	IsPurchased          BoolField
+	IsTrackedAsInventory BoolField
+	UpdatedDateUTC       DateField
+}
+
+//Item refers to the fields of Items e.g. query.Item.Code
+var Item = ItemFields{
+	ItemID:               "ItemID",
+	Code:                 "Code",
+	Name:                 "Name",
+	IsSold:               "IsSold",
+	IsPurchased:          "IsPurchased",
+	IsTrackedAsInventory: "IsTrackedAsInventory",
+	UpdatedDateUTC:       "UpdatedDateUTC",
+}
+
+//PurchaseOrderFields are the fields of PurchaseOrders that can be filtered and ordered on
+type PurchaseOrderFields struct {
+	PurchaseOrderID     GuidField
+	PurchaseOrderNumber StringField
+	Status              StringField
+	Reference           StringField
+	ContactID           GuidField
+	Date                DateField
+	DeliveryDate        DateField
+	UpdatedDateUTC      DateField
+	Total               NumberField
+}
+
+//PurchaseOrder refers to the fields of PurchaseOrders e.g. query.PurchaseOrder.PurchaseOrderNumber
+var PurchaseOrder = PurchaseOrderFields{
+	PurchaseOrderID:     "PurchaseOrderID",
+	PurchaseOrderNumber: "PurchaseOrderNumber",
+	Status:              "Status",
+	Reference:           "Reference",
+	ContactID:           "Contact.ContactID",
+	Date:                "Date",
+	DeliveryDate:        "DeliveryDate",
+	UpdatedDateUTC:      "UpdatedDateUTC",
+	Total:               "Total",
+}
@@ -0,0 +1,60 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//String returns s as a quoted string literal. Quotes are escaped by doubling them, as Xero's where parser expects
+func String(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+//Guid returns id as a Guid literal e.g. Guid("b7ba5d4b-...").
+//It panics if id is not a GUID; check ids from untrusted input with IsGuid first
+func Guid(id string) string {
+	if !IsGuid(id) {
+		panic(fmt.Sprintf("query: %q is not a GUID", id))
+	}
+	return `Guid("` + id + `")`
+}
+
+//IsGuid reports whether id is a GUID written as 32 hex digits in groups of 8-4-4-4-12
+func IsGuid(id string) bool {
+	if len(id) != 36 {
+		return false
+	}
+	for n, c := range id {
+		switch n {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+//DateTime returns t as a DateTime literal. The time of day is left out if it is midnight e.g. DateTime(2018, 06, 30)
+func DateTime(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return fmt.Sprintf("DateTime(%d, %02d, %02d)", t.Year(), t.Month(), t.Day())
+	}
+	return fmt.Sprintf("DateTime(%d, %02d, %02d, %02d, %02d, %02d)", t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second())
+}
+
+//Number returns n as a number literal
+func Number(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+//Bool returns b as a boolean literal
+func Bool(b bool) string {
+	return strconv.FormatBool(b)
+}
@@ -0,0 +1,73 @@
+//Package query builds the querystringParameters accepted by the Find functions of the accounting package,
+//so that where clauses don't have to be written by hand.
+//
+//	parameters := query.New().
+//		Where(query.Invoice.Status.Equals("AUTHORISED"), query.Invoice.ContactID.Equals(contactID)).
+//		OrderByDescending(query.Invoice.Date).
+//		Parameters()
+//
+//	invoices, err := accounting.FindInvoices(provider, session, parameters)
+package query
+
+import (
+	"strconv"
+	"strings"
+)
+
+//Query collects a where clause, an order and any other parameters for a Find function
+type Query struct {
+	where      []Condition
+	order      []string
+	parameters map[string]string
+}
+
+//New returns an empty query
+func New() *Query {
+	return &Query{
+		parameters: map[string]string{},
+	}
+}
+
+//Where adds conditions to the query. All of the conditions must match, as must those from earlier calls
+func (q *Query) Where(conditions ...Condition) *Query {
+	q.where = append(q.where, conditions...)
+	return q
+}
+
+//OrderBy sorts the results by field in ascending order. Later calls add further sort fields
+func (q *Query) OrderBy(field Field) *Query {
+	q.order = append(q.order, field.Name())
+	return q
+}
+
+//OrderByDescending sorts the results by field in descending order. Later calls add further sort fields
+func (q *Query) OrderByDescending(field Field) *Query {
+	q.order = append(q.order, field.Name()+" DESC")
+	return q
+}
+
+//Page requests a single page of results from a paged endpoint
+func (q *Query) Page(page int) *Query {
+	return q.Set("page", strconv.Itoa(page))
+}
+
+//Set adds any other querystring parameter e.g. Set("Statuses", "DRAFT,SUBMITTED")
+func (q *Query) Set(key string, value string) *Query {
+	q.parameters[key] = value
+	return q
+}
+
+//Parameters returns the querystringParameters to pass to a Find function
+func (q *Query) Parameters() map[string]string {
+	parameters := make(map[string]string, len(q.parameters)+2)
+	for key, value := range q.parameters {
+		parameters[key] = value
+	}
+	if where := And(q.where...); !where.IsEmpty() {
+		parameters["where"] = where.String()
+	}
+	if len(q.order) > 0 {
+		parameters["order"] = strings.Join(q.order, ",")
+	}
+	return parameters
+}
@@ -0,0 +1,72 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Query(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	parameters := New().
+		Where(Invoice.Status.Equals("AUTHORISED"), Invoice.ContactID.Equals("b7ba5d4b-0ae5-4fd6-9bf3-e4ea2c0ab7e6")).
+		Where(Invoice.Date.OnOrAfter(time.Date(2018, time.June, 1, 0, 0, 0, 0, time.UTC))).
+		OrderByDescending(Invoice.Date).
+		OrderBy(Invoice.InvoiceNumber).
+		Page(2).
+		Set("Statuses", "AUTHORISED,PAID").
+		Parameters()
+
+	a.Equal(map[string]string{
+		"where":    `Status=="AUTHORISED" AND Contact.ContactID==Guid("b7ba5d4b-0ae5-4fd6-9bf3-e4ea2c0ab7e6") AND Date>=DateTime(2018, 06, 01)`,
+		"order":    "Date DESC,InvoiceNumber",
+		"page":     "2",
+		"Statuses": "AUTHORISED,PAID",
+	}, parameters)
+
+	a.Equal(map[string]string{}, New().Parameters())
+}
+
+func Test_Condition_Grouping(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	condition := And(
+		Contact.IsSupplier.Is(true),
+		Or(Contact.Name.StartsWith("Acme"), Contact.EmailAddress.EndsWith("@acme.com")),
+		Condition{},
+	)
+	a.Equal(`IsSupplier==true AND (Name.StartsWith("Acme") OR EmailAddress.EndsWith("@acme.com"))`, condition.String())
+
+	a.Equal(`Type=="ACCREC"`, Or(Invoice.Type.Equals("ACCREC")).String())
+	a.True(And().IsEmpty())
+	a.Equal(`(Status=="DRAFT" OR Status=="SUBMITTED") AND Total>1000.5`, Invoice.Status.In("DRAFT", "SUBMITTED").And(Invoice.Total.GreaterThan(1000.5)).String())
+	a.Equal(`(Reference!=null) OR Total==0`, Raw("Reference!=null").Or(Invoice.Total.Equals(0)).String())
+}
+
+func Test_Literals(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	a.Equal(`Name=="Ben ""The Man"" O\Brien"`, Contact.Name.Equals(`Ben "The Man" O\Brien`).String())
+	a.Equal(`Reference.Contains("""\")`, Invoice.Reference.Contains(`"\`).String())
+	a.Equal(`Guid("B7BA5D4B-0ae5-4fd6-9bf3-e4ea2c0ab7e6")`, Guid("B7BA5D4B-0ae5-4fd6-9bf3-e4ea2c0ab7e6"))
+}
+
+func Test_Guid_RejectsNonGuids(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	for _, id := range []string{"", `")`, "b7ba5d4b0ae54fd69bf3e4ea2c0ab7e6", "{b7ba5d4b-0ae5-4fd6-9bf3-e4ea2c0ab7e6}", "b7ba5d4b-0ae5-4fd6-9bf3-e4ea2c0ab7eg", `b7ba5d4b-0ae5-4fd6-9bf3-e4ea2c0a")OR(`} {
+		a.False(IsGuid(id), id)
+		a.Panics(func() { Guid(id) }, id)
+		a.Panics(func() { Invoice.ContactID.Equals(id) }, id)
+	}
+	a.True(IsGuid("b7ba5d4b-0ae5-4fd6-9bf3-e4ea2c0ab7e6"))
+	a.Equal("DateTime(2018, 06, 30, 13, 05, 09)", DateTime(time.Date(2018, time.June, 30, 13, 5, 9, 0, time.UTC)))
+	a.Equal(`UpdatedDateUTC>=DateTime(2018, 01, 01) AND UpdatedDateUTC<=DateTime(2018, 12, 31)`,
+		BankTransaction.UpdatedDateUTC.Between(time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2018, time.December, 31, 0, 0, 0, 0, time.UTC)).String())
+}
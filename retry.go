@@ -0,0 +1,168 @@
+package xerogolang
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+//RetryPolicy determines how a Provider reacts to rate limiting and server errors from the Xero API.
+//A Provider with a nil RetryPolicy will not retry any requests.
+type RetryPolicy struct {
+	//MaxRetries is the number of times a request will be retried before the error is returned
+	MaxRetries int
+
+	//MinBackoff is the delay before the first retry of a server error. It doubles on each subsequent retry
+	MinBackoff time.Duration
+
+	//MaxBackoff caps the delay between retries of a server error
+	MaxBackoff time.Duration
+
+	//MaxRetryAfter is the longest Retry-After delay that will be waited out.
+	//If Xero asks us to wait longer than this the error is returned straight away. Zero means no limit
+	MaxRetryAfter time.Duration
+}
+
+//DefaultRetryPolicy returns a RetryPolicy suitable for most applications.
+//It will wait out the minute limit but gives up if the daily limit has been reached.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:    3,
+		MinBackoff:    500 * time.Millisecond,
+		MaxBackoff:    10 * time.Second,
+		MaxRetryAfter: 2 * time.Minute,
+	}
+}
+
+//RateLimit is the state of the Xero API limits for an organisation as reported by the headers on the responses to its requests.
+//Values Xero has not reported yet are -1. See https://developer.xero.com/documentation/auth-and-limits/xero-api-limits
+type RateLimit struct {
+	//MinuteRemaining is the number of calls left in the current minute for this organisation
+	MinuteRemaining int
+
+	//DayRemaining is the number of calls left today for this organisation
+	DayRemaining int
+
+	//AppMinuteRemaining is the number of calls left in the current minute for the whole application
+	AppMinuteRemaining int
+
+	//Problem is the limit that was exceeded (minute, day or appminute) when a request was last rate limited
+	Problem string
+
+	//RetryAfter is how long Xero asked us to wait when a request was last rate limited
+	RetryAfter time.Duration
+
+	//UpdatedAt is when the headers were last received
+	UpdatedAt time.Time
+}
+
+//unknownRateLimit is the RateLimit of an organisation no responses have been received for
+var unknownRateLimit = RateLimit{MinuteRemaining: -1, DayRemaining: -1, AppMinuteRemaining: -1}
+
+//update copies the limits reported in latest, leaving the ones its response had no headers for unchanged
+func (r *RateLimit) update(latest RateLimit) {
+	updated := false
+	if latest.MinuteRemaining >= 0 {
+		r.MinuteRemaining, updated = latest.MinuteRemaining, true
+	}
+	if latest.DayRemaining >= 0 {
+		r.DayRemaining, updated = latest.DayRemaining, true
+	}
+	if latest.AppMinuteRemaining >= 0 {
+		r.AppMinuteRemaining, updated = latest.AppMinuteRemaining, true
+	}
+	if latest.Problem != "" {
+		r.Problem, updated = latest.Problem, true
+	}
+	if latest.RetryAfter > 0 {
+		r.RetryAfter, updated = latest.RetryAfter, true
+	}
+	if updated {
+		r.UpdatedAt = latest.UpdatedAt
+	}
+}
+
+//rateLimitFromHeader reads the rate limit headers from a response. Headers that are missing are returned as -1
+func rateLimitFromHeader(header http.Header) RateLimit {
+	return RateLimit{
+		MinuteRemaining:    headerInt(header, "X-MinLimit-Remaining"),
+		DayRemaining:       headerInt(header, "X-DayLimit-Remaining"),
+		AppMinuteRemaining: headerInt(header, "X-AppMinLimit-Remaining"),
+		Problem:            header.Get("X-Rate-Limit-Problem"),
+		RetryAfter:         retryAfter(header),
+		UpdatedAt:          time.Now().UTC(),
+	}
+}
+
+func headerInt(header http.Header, key string) int {
+	value, err := strconv.Atoi(header.Get(key))
+	if err != nil {
+		return -1
+	}
+	return value
+}
+
+//retryAfter parses a Retry-After header which can either be a number of seconds or a HTTP date
+func retryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+//delay returns how long to wait before retrying a request and whether it should be retried at all
+func (r *RetryPolicy) delay(attempt int, request *http.Request, response *http.Response, rateLimit RateLimit) (time.Duration, bool) {
+	if r == nil || attempt >= r.MaxRetries {
+		return 0, false
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests:
+		//The daily limit won't reset for hours so there is no point waiting for it
+		if rateLimit.Problem == "day" {
+			return 0, false
+		}
+		if rateLimit.RetryAfter > 0 {
+			if r.MaxRetryAfter > 0 && rateLimit.RetryAfter > r.MaxRetryAfter {
+				return 0, false
+			}
+			return rateLimit.RetryAfter, true
+		}
+		return r.backoff(attempt), true
+	case http.StatusServiceUnavailable:
+		if rateLimit.RetryAfter > 0 && (r.MaxRetryAfter == 0 || rateLimit.RetryAfter <= r.MaxRetryAfter) {
+			return rateLimit.RetryAfter, true
+		}
+		return r.backoff(attempt), true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		//The request may have been processed so only retry when repeating it is harmless
+		if request.Method == "GET" || request.Method == "DELETE" {
+			return r.backoff(attempt), true
+		}
+	}
+
+	return 0, false
+}
+
+//backoff is an exponential backoff with jitter so that concurrent clients don't retry in lockstep
+func (r *RetryPolicy) backoff(attempt int) time.Duration {
+	wait := r.MinBackoff << uint(attempt)
+	if wait <= 0 || (r.MaxBackoff > 0 && wait > r.MaxBackoff) {
+		wait = r.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
@@ -1,6 +1,7 @@
 package xerogolang
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/markbates/goth"
 	"github.com/mrjones/oauth"
+	"golang.org/x/oauth2"
 )
 
 // Session stores data during the auth process with Xero.
@@ -17,6 +19,12 @@ type Session struct {
 	AccessToken        *oauth.AccessToken
 	RequestToken       *oauth.RequestToken
 	AccessTokenExpires time.Time
+	//OAuth2AccessToken is the bearer token used by OAuth 2.0 sessions
+	OAuth2AccessToken string `json:",omitempty"`
+	//RefreshToken is used by OAuth 2.0 sessions to get a new access token when it expires
+	RefreshToken string `json:",omitempty"`
+	//TenantID is the organisation requests are sent to, as the Xero-tenant-id header. See Provider.FindConnections
+	TenantID string `json:",omitempty"`
 }
 
 // GetAuthURL will return the URL set by calling the `BeginAuth` function on the Xero provider.
@@ -30,6 +38,13 @@ func (s Session) GetAuthURL() (string, error) {
 // Authorize the session with Xero and return the access token to be stored for future use.
 func (s *Session) Authorize(provider goth.Provider, params goth.Params) (string, error) {
 	p := provider.(*Provider)
+	if p.isOAuth2() {
+		token, err := p.authorizeOAuth2(context.Background(), s, params.Get("code"))
+		if err != nil {
+			return "", err
+		}
+		return token, p.selectDefaultTenant(context.Background(), s)
+	}
 	if p.Method == "private" {
 		privateAccessToken := &oauth.AccessToken{
 			Token:  p.ClientKey,
@@ -43,7 +58,11 @@ func (s *Session) Authorize(provider goth.Provider, params goth.Params) (string,
 	if s.RequestToken == nil {
 		return "", fmt.Errorf("Missing Request Token")
 	}
-	accessToken, err := p.consumer.AuthorizeToken(s.RequestToken, params.Get("oauth_verifier"))
+	consumer, err := p.oauthConsumer()
+	if err != nil {
+		return "", err
+	}
+	accessToken, err := consumer.AuthorizeToken(s.RequestToken, params.Get("oauth_verifier"))
 	if err != nil {
 		return "", err
 	}
@@ -54,6 +73,23 @@ func (s *Session) Authorize(provider goth.Provider, params goth.Params) (string,
 	return accessToken.Token, nil
 }
 
+//setOAuth2Token stores the tokens returned by the OAuth 2.0 token endpoint
+func (s *Session) setOAuth2Token(token *oauth2.Token) {
+	s.OAuth2AccessToken = token.AccessToken
+	if token.RefreshToken != "" {
+		s.RefreshToken = token.RefreshToken
+	}
+	s.AccessTokenExpires = token.Expiry.UTC()
+}
+
+//needsOAuth2Refresh reports whether the session's OAuth 2.0 access token is about to expire and can be refreshed
+func (s *Session) needsOAuth2Refresh() bool {
+	if s.OAuth2AccessToken == "" || s.RefreshToken == "" || s.AccessTokenExpires.IsZero() {
+		return false
+	}
+	return !s.AccessTokenExpires.After(time.Now().UTC().Add(oauth2RefreshWindow))
+}
+
 // Marshal the session into a string
 func (s Session) Marshal() string {
 	b, _ := json.Marshal(s)
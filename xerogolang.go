@@ -1,18 +1,15 @@
 package xerogolang
 
 import (
-	"bytes"
-	"crypto/x509"
+	"context"
 	"encoding/json"
-	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
-	"net/url"
 	"os"
-	"strings"
+	"sync"
 	"time"
 
 	"crypto"
@@ -21,21 +18,23 @@ import (
 	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 	"github.com/mrjones/oauth"
-	"golang.org/x/oauth2"
 )
 
-var (
-	requestURL      = "https://api.xero.com/oauth/RequestToken"
-	authorizeURL    = "https://api.xero.com/oauth/Authorize"
-	tokenURL        = "https://api.xero.com/oauth/AccessToken"
-	endpointProfile = "https://api.xero.com/api.xro/2.0/"
-	//userAgentString should match the name of your Application
-	userAgentString = os.Getenv("XERO_USER_AGENT") + " (xerogolang 0.1.3) " + os.Getenv("XERO_KEY")
-	//privateKeyFilePath is a file path to your .pem private/public key file
-	//You only need this for private and partner Applications
-	//more details here: https://developer.xero.com/documentation/api-guides/create-publicprivate-key
-	privateKeyFilePath = os.Getenv("XERO_PRIVATE_KEY_PATH")
-)
+//userAgentFor builds the User-Agent sent with every request - appName should match the name of your Application
+func userAgentFor(appName, clientKey string) string {
+	return appName + " (xerogolang 0.1.3) " + clientKey
+}
+
+//privateKeyFromEnvironment reads the private key from the file at XERO_PRIVATE_KEY_PATH, if it is set.
+//You only need this for private and partner Applications
+//more details here: https://developer.xero.com/documentation/api-guides/create-publicprivate-key
+func privateKeyFromEnvironment() (string, error) {
+	privateKeyPath := os.Getenv("XERO_PRIVATE_KEY_PATH")
+	if privateKeyPath == "" {
+		return "", nil
+	}
+	return helpers.LoadPrivateKeyFromPath(privateKeyPath)
+}
 
 // Provider is the implementation of `goth.Provider` for accessing Xero.
 type Provider struct {
@@ -46,13 +45,39 @@ type Provider struct {
 	Method          string
 	UserAgentString string
 	PrivateKey      string
-	debug           bool
-	consumer        *oauth.Consumer
-	providerName    string
+	//PrivateKeyPassphrase decrypts PrivateKey when it has been encrypted
+	PrivateKeyPassphrase string
+	//Endpoints are the URLs of Xero's APIs and OAuth endpoints. Any left empty use DefaultEndpoints
+	Endpoints Endpoints
+	//Scopes are the OAuth 2.0 scopes requested by BeginAuth. DefaultOAuth2Scopes are used when it is empty
+	Scopes []string
+	//RetryPolicy controls how rate limited and failed requests are retried. Requests are not retried when it is nil
+	RetryPolicy *RetryPolicy
+	//Middleware is applied in order to every request sent to the API
+	Middleware []Middleware
+	//Logger is told about failed and retried requests. Nothing is logged when it is nil
+	Logger Logger
+	//OnTokenRefresh is called after an OAuth 2.0 session's tokens have been refreshed so the session can be
+	//stored again. Xero rotates refresh tokens, so the session's old refresh token can no longer be used.
+	//An error returned from it is returned from the request that refreshed the tokens
+	OnTokenRefresh func(session *Session) error
+
+	debug          bool
+	rateLimits     map[string]RateLimit
+	rateLimitMutex sync.Mutex
+	consumer       *oauth.Consumer
+	consumerOnce   sync.Once
+	//privateKeyErr is why the private key at XERO_PRIVATE_KEY_PATH could not be read by New or NewCustomHTTPClient
+	privateKeyErr  error
+	tokenMutex     sync.Mutex
+	tokenRefreshes map[*Session]*tokenRefresh
+	consumerErr    error
+	providerName   string
 }
 
 //newPublicConsumer creates a consumer capable of communicating with a Public application: https://developer.xero.com/documentation/auth-and-limits/public-applications
-func (p *Provider) newPublicConsumer(authURL string) *oauth.Consumer {
+func (p *Provider) newPublicConsumer() *oauth.Consumer {
+	endpoints := p.endpoints()
 
 	var c *oauth.Consumer
 
@@ -61,9 +86,9 @@ func (p *Provider) newPublicConsumer(authURL string) *oauth.Consumer {
 			p.ClientKey,
 			p.Secret,
 			oauth.ServiceProvider{
-				RequestTokenUrl:   requestURL,
-				AuthorizeTokenUrl: authURL,
-				AccessTokenUrl:    tokenURL},
+				RequestTokenUrl:   endpoints.RequestTokenURL,
+				AuthorizeTokenUrl: endpoints.AuthorizeURL,
+				AccessTokenUrl:    endpoints.AccessTokenURL},
 			p.HTTPClient,
 		)
 	} else {
@@ -71,9 +96,9 @@ func (p *Provider) newPublicConsumer(authURL string) *oauth.Consumer {
 			p.ClientKey,
 			p.Secret,
 			oauth.ServiceProvider{
-				RequestTokenUrl:   requestURL,
-				AuthorizeTokenUrl: authURL,
-				AccessTokenUrl:    tokenURL},
+				RequestTokenUrl:   endpoints.RequestTokenURL,
+				AuthorizeTokenUrl: endpoints.AuthorizeURL,
+				AccessTokenUrl:    endpoints.AccessTokenURL},
 		)
 	}
 
@@ -83,12 +108,18 @@ func (p *Provider) newPublicConsumer(authURL string) *oauth.Consumer {
 }
 
 //newPartnerConsumer creates a consumer capable of communicating with a Partner application: https://developer.xero.com/documentation/auth-and-limits/partner-applications
-func (p *Provider) newPrivateOrPartnerConsumer(authURL string) *oauth.Consumer {
-	block, _ := pem.Decode([]byte(p.PrivateKey))
+func (p *Provider) newPrivateOrPartnerConsumer() (*oauth.Consumer, error) {
+	endpoints := p.endpoints()
+	if p.PrivateKey == "" && p.privateKeyErr != nil {
+		return nil, p.privateKeyErr
+	}
+	if p.PrivateKey == "" {
+		return nil, fmt.Errorf("a private key is required for %s applications", p.Method)
+	}
 
-	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	privateKey, err := helpers.ParseRSAPrivateKey([]byte(p.PrivateKey), []byte(p.PrivateKeyPassphrase))
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	var c *oauth.Consumer
@@ -99,9 +130,9 @@ func (p *Provider) newPrivateOrPartnerConsumer(authURL string) *oauth.Consumer {
 			privateKey,
 			crypto.SHA1,
 			oauth.ServiceProvider{
-				RequestTokenUrl:   requestURL,
-				AuthorizeTokenUrl: authURL,
-				AccessTokenUrl:    tokenURL},
+				RequestTokenUrl:   endpoints.RequestTokenURL,
+				AuthorizeTokenUrl: endpoints.AuthorizeURL,
+				AccessTokenUrl:    endpoints.AccessTokenURL},
 			p.HTTPClient,
 		)
 	} else {
@@ -109,15 +140,15 @@ func (p *Provider) newPrivateOrPartnerConsumer(authURL string) *oauth.Consumer {
 			p.ClientKey,
 			privateKey,
 			oauth.ServiceProvider{
-				RequestTokenUrl:   requestURL,
-				AuthorizeTokenUrl: authURL,
-				AccessTokenUrl:    tokenURL},
+				RequestTokenUrl:   endpoints.RequestTokenURL,
+				AuthorizeTokenUrl: endpoints.AuthorizeURL,
+				AccessTokenUrl:    endpoints.AccessTokenURL},
 		)
 	}
 
 	c.Debug(p.debug)
 
-	return c
+	return c, nil
 }
 
 // New creates a new Xero provider, and sets up important connection details.
@@ -133,10 +164,10 @@ func New(clientKey, secret, callbackURL string) *Provider {
 		//Use public if this is your first time.
 		//More details here: https://developer.xero.com/documentation/getting-started/api-application-types
 		Method:          os.Getenv("XERO_METHOD"),
-		PrivateKey:      helpers.ReadPrivateKeyFromPath(privateKeyFilePath),
-		UserAgentString: userAgentString,
+		UserAgentString: userAgentFor(os.Getenv("XERO_USER_AGENT"), os.Getenv("XERO_KEY")),
 		providerName:    "xero",
 	}
+	p.PrivateKey, p.privateKeyErr = privateKeyFromEnvironment()
 	return p
 }
 
@@ -145,10 +176,6 @@ func New(clientKey, secret, callbackURL string) *Provider {
 // You should always call `xero.New` to get a new Provider. Never try to create
 // one manually.
 func NewNoEnviro(clientKey, secret, callbackURL, userAgent, xeroMethod string, privateKey []byte) *Provider {
-	// Set variables without using the environment
-	userAgentString = userAgent + " (xerogolang 0.1.3) " + clientKey
-	privateKeyFilePath = ""
-
 	p := &Provider{
 		ClientKey:   clientKey,
 		Secret:      secret,
@@ -159,12 +186,26 @@ func NewNoEnviro(clientKey, secret, callbackURL, userAgent, xeroMethod string, p
 		//More details here: https://developer.xero.com/documentation/getting-started/api-application-types
 		Method:          xeroMethod,
 		PrivateKey:      string(privateKey),
-		UserAgentString: userAgentString,
+		UserAgentString: userAgentFor(userAgent, clientKey),
 		providerName:    "xero",
 	}
 	return p
 }
 
+//NewWithPrivateKey creates a new Xero provider without using the environment, like NewNoEnviro,
+//but validates the configuration and private key straight away and returns an error if there is a problem.
+//privateKey can be PKCS#1 or PKCS#8 PEM and passphrase is only needed if it is encrypted
+func NewWithPrivateKey(clientKey, secret, callbackURL, userAgent, xeroMethod string, privateKey []byte, passphrase []byte) (*Provider, error) {
+	p := NewNoEnviro(clientKey, secret, callbackURL, userAgent, xeroMethod, privateKey)
+	p.PrivateKeyPassphrase = string(passphrase)
+
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
 // New creates a new Xero provider, with a custom http client
 func NewCustomHTTPClient(clientKey, secret, callbackURL string, httpClient *http.Client) *Provider {
 	p := &Provider{
@@ -173,11 +214,11 @@ func NewCustomHTTPClient(clientKey, secret, callbackURL string, httpClient *http
 		CallbackURL: callbackURL,
 
 		Method:          os.Getenv("XERO_METHOD"),
-		PrivateKey:      helpers.ReadPrivateKeyFromPath(privateKeyFilePath),
-		UserAgentString: userAgentString,
+		UserAgentString: userAgentFor(os.Getenv("XERO_USER_AGENT"), os.Getenv("XERO_KEY")),
 		providerName:    "xero",
 		HTTPClient:      httpClient,
 	}
+	p.PrivateKey, p.privateKeyErr = privateKeyFromEnvironment()
 	return p
 }
 
@@ -202,10 +243,16 @@ func (p *Provider) Debug(debug bool) {
 }
 
 // BeginAuth asks Xero for an authentication end-point and a request token for a session.
-// Xero does not support the "state" variable.
+// With OAuth 2.0 the "state" variable is passed to Xero and returned to the callback URL, where it
+// should be checked to protect against CSRF. OAuth 1.0a does not support it and it is ignored.
 func (p *Provider) BeginAuth(state string) (goth.Session, error) {
-	if p.consumer == nil {
-		p.initConsumer()
+	if p.isOAuth2() {
+		return p.beginOAuth2(state), nil
+	}
+
+	consumer, err := p.oauthConsumer()
+	if err != nil {
+		return nil, err
 	}
 
 	if p.Method == "private" {
@@ -214,14 +261,14 @@ func (p *Provider) BeginAuth(state string) (goth.Session, error) {
 			Secret: p.Secret,
 		}
 		privateSession := &Session{
-			AuthURL:            authorizeURL,
+			AuthURL:            p.endpoints().AuthorizeURL,
 			RequestToken:       nil,
 			AccessToken:        accessToken,
 			AccessTokenExpires: time.Now().UTC().Add(87600 * time.Hour),
 		}
 		return privateSession, nil
 	}
-	requestToken, url, err := p.consumer.GetRequestTokenAndUrl(p.CallbackURL)
+	requestToken, url, err := consumer.GetRequestTokenAndUrl(p.CallbackURL)
 	if err != nil {
 		return nil, err
 	}
@@ -233,113 +280,222 @@ func (p *Provider) BeginAuth(state string) (goth.Session, error) {
 }
 
 //processRequest processes a request prior to it being sent to the API
-func (p *Provider) processRequest(request *http.Request, session goth.Session, additionalHeaders map[string]string) ([]byte, error) {
-	sess := session.(*Session)
+func (p *Provider) processRequest(request *http.Request, session goth.Session, endpoint string, additionalHeaders map[string]string) ([]byte, error) {
+	response, err := p.doRequest(request, session, endpoint, additionalHeaders)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
 
-	if p.consumer == nil {
-		p.initConsumer()
+	responseBytes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read response: %s", err.Error())
+	}
+	if responseBytes == nil {
+		return nil, fmt.Errorf("Received no response: %s", err.Error())
 	}
+	return responseBytes, nil
+}
+
+//doRequest authenticates and sends a request, retrying according to the RetryPolicy.
+//It returns the first successful response with its body unread; the caller must close it
+func (p *Provider) doRequest(request *http.Request, session goth.Session, endpoint string, additionalHeaders map[string]string) (*http.Response, error) {
+	sess := session.(*Session)
+
+	var accessToken string
+	if p.isOAuth2() {
+		var err error
+		accessToken, err = p.ensureOAuth2Token(request.Context(), sess)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := p.oauthConsumer(); err != nil {
+			return nil, err
+		}
 
-	if sess.AccessToken == nil {
-		// data is not yet retrieved since accessToken is still empty
-		return nil, fmt.Errorf("%s cannot process request without accessToken", p.providerName)
+		if sess.AccessToken == nil {
+			// data is not yet retrieved since accessToken is still empty
+			return nil, fmt.Errorf("%s cannot process request without accessToken", p.providerName)
+		}
 	}
 
 	request.Header.Add("User-Agent", p.UserAgentString)
+	if sess.TenantID != "" {
+		request.Header.Set("Xero-tenant-id", sess.TenantID)
+	}
 	for key, value := range additionalHeaders {
 		request.Header.Add(key, value)
 	}
 
-	var err error
-	var response *http.Response
+	roundTrip := p.chain(endpoint, func(request *http.Request) (*http.Response, error) {
+		return p.send(request, sess, accessToken)
+	})
 
-	if p.HTTPClient == nil {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request.Body = body
+		}
 
-		client, _ := p.consumer.MakeHttpClient(sess.AccessToken)
+		response, err := roundTrip(request)
+		if err != nil {
+			p.logf("%s %s failed: %s", request.Method, endpoint, err.Error())
+			return nil, err
+		}
 
-		response, err = client.Do(request)
+		rateLimit := rateLimitFromHeader(response.Header)
+		p.setRateLimit(sess.TenantID, rateLimit)
 
-	} else {
+		if response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+			return response, nil
+		}
 
-		transport, _ := p.consumer.MakeRoundTripper(sess.AccessToken)
+		wait, retry := p.RetryPolicy.delay(attempt, request, response, rateLimit)
+		if !retry {
+			defer response.Body.Close()
 
-		response, err = transport.RoundTrip(request)
+			apiError := newAPIError(response)
+			p.logf("%s %s failed: %s", request.Method, endpoint, apiError.Error())
+			return nil, apiError
+		}
+		response.Body.Close()
+		p.logf("%s %s returned %d, retrying in %s", request.Method, endpoint, response.StatusCode, wait)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+//send signs the request with the session's access token and sends it to the API.
+//accessToken is the OAuth 2.0 access token returned by ensureOAuth2Token
+func (p *Provider) send(request *http.Request, sess *Session, accessToken string) (*http.Response, error) {
+	if p.isOAuth2() {
+		request.Header.Set("Authorization", "Bearer "+accessToken)
+
+		return p.Client().Do(request)
 	}
 
+	consumer, err := p.oauthConsumer()
 	if err != nil {
 		return nil, err
 	}
 
-	defer response.Body.Close()
+	if p.HTTPClient == nil {
 
-	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf(
-			helpers.ReaderToString(response.Body),
-		)
+		client, _ := consumer.MakeHttpClient(sess.AccessToken)
+
+		return client.Do(request)
 	}
 
-	responseBytes, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return nil, fmt.Errorf("Could not read response: %s", err.Error())
+	transport, _ := consumer.MakeRoundTripper(sess.AccessToken)
+
+	return transport.RoundTrip(request)
+}
+
+//RateLimit returns the API limits Xero has reported for the organisation with the given tenantID.
+//Xero counts the minute and daily limits separately for each organisation. Sessions that don't
+//select an organisation, such as those of OAuth 1.0a applications, use an empty tenantID
+func (p *Provider) RateLimit(tenantID string) RateLimit {
+	p.rateLimitMutex.Lock()
+	defer p.rateLimitMutex.Unlock()
+	if rateLimit, ok := p.rateLimits[tenantID]; ok {
+		return rateLimit
 	}
-	if responseBytes == nil {
-		return nil, fmt.Errorf("Received no response: %s", err.Error())
+	return unknownRateLimit
+}
+
+//setRateLimit updates the limits of the organisation with the headers on a response to one of its requests.
+//The application's minute limit is shared by every organisation, so it is updated for all of them
+func (p *Provider) setRateLimit(tenantID string, latest RateLimit) {
+	p.rateLimitMutex.Lock()
+	defer p.rateLimitMutex.Unlock()
+	if p.rateLimits == nil {
+		p.rateLimits = map[string]RateLimit{}
+	}
+
+	rateLimit, ok := p.rateLimits[tenantID]
+	if !ok {
+		rateLimit = unknownRateLimit
+	}
+	rateLimit.update(latest)
+	p.rateLimits[tenantID] = rateLimit
+
+	if latest.AppMinuteRemaining >= 0 {
+		for otherTenantID, otherRateLimit := range p.rateLimits {
+			otherRateLimit.AppMinuteRemaining = latest.AppMinuteRemaining
+			p.rateLimits[otherTenantID] = otherRateLimit
+		}
 	}
-	return responseBytes, nil
 }
 
 //Find retrieves the requested data from an endpoint to be unmarshaled into the appropriate data type
 func (p *Provider) Find(session goth.Session, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string) ([]byte, error) {
-	var querystring string
-	if querystringParameters != nil {
-		for key, value := range querystringParameters {
-			escapedValue := url.QueryEscape(value)
-			querystring = querystring + "&" + key + "=" + escapedValue
-		}
-		querystring = strings.TrimPrefix(querystring, "&")
-		querystring = "?" + querystring
-	}
+	return p.FindContext(context.Background(), session, endpoint, additionalHeaders, querystringParameters)
+}
 
-	request, err := http.NewRequest("GET", endpointProfile+endpoint+querystring, nil)
-	if err != nil {
-		return nil, err
-	}
+//FindContext is like Find but uses ctx for the request so that it can be cancelled or given a deadline
+func (p *Provider) FindContext(ctx context.Context, session goth.Session, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string) ([]byte, error) {
+	return p.API(AccountingAPI).FindContext(ctx, session, endpoint, additionalHeaders, querystringParameters)
+}
+
+//FindStream is like Find but returns the body of the response as it arrives instead of reading it into memory.
+//The caller must close the returned reader
+func (p *Provider) FindStream(session goth.Session, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string) (io.ReadCloser, error) {
+	return p.FindStreamContext(context.Background(), session, endpoint, additionalHeaders, querystringParameters)
+}
+
+//FindStreamContext is like FindStream but uses ctx for the request so that it can be cancelled or given a deadline
+func (p *Provider) FindStreamContext(ctx context.Context, session goth.Session, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string) (io.ReadCloser, error) {
+	return p.API(AccountingAPI).FindStreamContext(ctx, session, endpoint, additionalHeaders, querystringParameters)
+}
+
+//Send sends body to an endpoint using any HTTP method along with querystring parameters
+func (p *Provider) Send(session goth.Session, method string, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string, body []byte) ([]byte, error) {
+	return p.SendContext(context.Background(), session, method, endpoint, additionalHeaders, querystringParameters, body)
+}
 
-	return p.processRequest(request, session, additionalHeaders)
+//SendContext is like Send but uses ctx for the request so that it can be cancelled or given a deadline
+func (p *Provider) SendContext(ctx context.Context, session goth.Session, method string, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string, body []byte) ([]byte, error) {
+	return p.API(AccountingAPI).SendContext(ctx, session, method, endpoint, additionalHeaders, querystringParameters, body)
 }
 
 //Create sends data to an endpoint and returns a response to be unmarshaled into the appropriate data type
 func (p *Provider) Create(session goth.Session, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
-	bodyReader := bytes.NewReader(body)
-
-	request, err := http.NewRequest("PUT", endpointProfile+endpoint, bodyReader)
-	if err != nil {
-		return nil, err
-	}
+	return p.CreateContext(context.Background(), session, endpoint, additionalHeaders, body)
+}
 
-	return p.processRequest(request, session, additionalHeaders)
+//CreateContext is like Create but uses ctx for the request so that it can be cancelled or given a deadline
+func (p *Provider) CreateContext(ctx context.Context, session goth.Session, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
+	return p.API(AccountingAPI).CreateContext(ctx, session, endpoint, additionalHeaders, body)
 }
 
 //Update sends data to an endpoint and returns a response to be unmarshaled into the appropriate data type
 func (p *Provider) Update(session goth.Session, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
-	bodyReader := bytes.NewReader(body)
-
-	request, err := http.NewRequest("POST", endpointProfile+endpoint, bodyReader)
-	if err != nil {
-		return nil, err
-	}
+	return p.UpdateContext(context.Background(), session, endpoint, additionalHeaders, body)
+}
 
-	return p.processRequest(request, session, additionalHeaders)
+//UpdateContext is like Update but uses ctx for the request so that it can be cancelled or given a deadline
+func (p *Provider) UpdateContext(ctx context.Context, session goth.Session, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
+	return p.API(AccountingAPI).UpdateContext(ctx, session, endpoint, additionalHeaders, body)
 }
 
 //Remove deletes the specified data from an endpoint
 func (p *Provider) Remove(session goth.Session, endpoint string, additionalHeaders map[string]string) ([]byte, error) {
-	request, err := http.NewRequest("DELETE", endpointProfile+endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
+	return p.RemoveContext(context.Background(), session, endpoint, additionalHeaders)
+}
 
-	return p.processRequest(request, session, additionalHeaders)
+//RemoveContext is like Remove but uses ctx for the request so that it can be cancelled or given a deadline
+func (p *Provider) RemoveContext(ctx context.Context, session goth.Session, endpoint string, additionalHeaders map[string]string) ([]byte, error) {
+	return p.API(AccountingAPI).RemoveContext(ctx, session, endpoint, additionalHeaders)
 }
 
 //Organisation is the expected response from the Organisation endpoint - this is not a complete schema
@@ -384,6 +540,9 @@ func (p *Provider) FetchUser(session goth.Session) (goth.User, error) {
 	if err != nil {
 		return user, fmt.Errorf("Could not unmarshal response: %s", err.Error())
 	}
+	if len(organisationCollection.Organisations) == 0 {
+		return user, errors.New("Could not find an organisation for this session")
+	}
 
 	user.Name = organisationCollection.Organisations[0].Name
 	user.NickName = organisationCollection.Organisations[0].LegalName
@@ -391,8 +550,13 @@ func (p *Provider) FetchUser(session goth.Session) (goth.User, error) {
 	user.Description = organisationCollection.Organisations[0].OrganisationType
 	user.UserID = organisationCollection.Organisations[0].ShortCode
 
-	user.AccessToken = sess.AccessToken.Token
-	user.AccessTokenSecret = sess.AccessToken.Secret
+	if p.isOAuth2() {
+		user.AccessToken = sess.OAuth2AccessToken
+		user.RefreshToken = sess.RefreshToken
+	} else {
+		user.AccessToken = sess.AccessToken.Token
+		user.AccessTokenSecret = sess.AccessToken.Secret
+	}
 	user.ExpiresAt = sess.AccessTokenExpires
 	user.Email = p.Method
 	return user, err
@@ -400,13 +564,14 @@ func (p *Provider) FetchUser(session goth.Session) (goth.User, error) {
 
 //RefreshOAuth1Token should be used instead of RefeshToken which is not compliant with the Oauth1.0a standard
 func (p *Provider) RefreshOAuth1Token(session *Session) error {
-	if p.consumer == nil {
-		p.initConsumer()
-	}
 	if session.AccessToken == nil {
 		return fmt.Errorf("Could not refresh token as last valid accessToken was not found")
 	}
-	newAccessToken, err := p.consumer.RefreshToken(session.AccessToken)
+	consumer, err := p.oauthConsumer()
+	if err != nil {
+		return err
+	}
+	newAccessToken, err := consumer.RefreshToken(session.AccessToken)
 	if err != nil {
 		return err
 	}
@@ -415,18 +580,6 @@ func (p *Provider) RefreshOAuth1Token(session *Session) error {
 	return nil
 }
 
-//RefreshToken refresh token is not provided by the Xero Public or Private Application -
-//only the Partner Application and you must use RefreshOAuth1Token instead
-func (p *Provider) RefreshToken(refreshToken string) (*oauth2.Token, error) {
-	return nil, errors.New("Refresh token is only provided by Xero for Partner Applications")
-}
-
-//RefreshTokenAvailable refresh token is not provided by the Xero Public or Private Application -
-//only the Partner Application and you must use RefreshOAuth1Token instead
-func (p *Provider) RefreshTokenAvailable() bool {
-	return false
-}
-
 //GetSessionFromStore returns a session for a given a request and a response
 //This is an exaple of how you could get a session from a store - as long as you're
 //supplying a goth.Session to the interactors it will work though so feel free to use your
@@ -449,20 +602,55 @@ func (p *Provider) GetSessionFromStore(request *http.Request, response http.Resp
 			err = sessionMarshalled.Save(request, response)
 			return session, err
 		}
+		if p.isOAuth2() && sess.RefreshToken != "" {
+			err = p.RefreshOAuth2Token(request.Context(), sess)
+			if err != nil {
+				return nil, err
+			}
+			sessionMarshalled.Values["xero"] = sess.Marshal()
+			err = sessionMarshalled.Save(request, response)
+			return session, err
+		}
 		return nil, errors.New("access token has expired - please reconnect")
 	}
 	return session, err
 }
 
+//oauthConsumer returns the OAuth 1.0a consumer, creating it on first use.
+//It is safe to call from multiple goroutines
+func (p *Provider) oauthConsumer() (*oauth.Consumer, error) {
+	p.consumerOnce.Do(p.initConsumer)
+	return p.consumer, p.consumerErr
+}
+
 func (p *Provider) initConsumer() {
 	switch p.Method {
 	case "private":
-		p.consumer = p.newPrivateOrPartnerConsumer(authorizeURL)
+		p.consumer, p.consumerErr = p.newPrivateOrPartnerConsumer()
 	case "public":
-		p.consumer = p.newPublicConsumer(authorizeURL)
+		p.consumer = p.newPublicConsumer()
 	case "partner":
-		p.consumer = p.newPrivateOrPartnerConsumer(authorizeURL)
+		p.consumer, p.consumerErr = p.newPrivateOrPartnerConsumer()
 	default:
-		p.consumer = p.newPublicConsumer(authorizeURL)
+		p.consumer = p.newPublicConsumer()
 	}
 }
+
+//Validate checks the provider's configuration, including parsing the private key of private and partner applications,
+//so that problems are found when the provider is created rather than on the first request
+func (p *Provider) Validate() error {
+	switch p.Method {
+	case "", "public":
+	case "private", "partner":
+		if _, err := p.oauthConsumer(); err != nil {
+			return err
+		}
+	case "oauth2":
+		if p.ClientKey == "" || p.Secret == "" {
+			return errors.New("a client id and secret are required for OAuth 2.0")
+		}
+	default:
+		return fmt.Errorf("unknown method %q - use public, private, partner or oauth2", p.Method)
+	}
+	return nil
+}
@@ -1,13 +1,17 @@
 package xerogolang
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gorilla/pat"
 	"github.com/markbates/goth"
@@ -37,7 +41,7 @@ func Test_BeginAuth(t *testing.T) {
 	a := assert.New(t)
 
 	mockXero(func(ts *httptest.Server) {
-		provider := xeroProvider()
+		provider := mockProvider(ts)
 		session, err := provider.BeginAuth("state")
 		if err != nil {
 			a.Error(err, nil)
@@ -55,7 +59,7 @@ func Test_FetchUser(t *testing.T) {
 	a := assert.New(t)
 
 	mockXero(func(ts *httptest.Server) {
-		provider := xeroProvider()
+		provider := mockProvider(ts)
 		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
 
 		user, err := provider.FetchUser(&session)
@@ -94,7 +98,7 @@ func Test_Find(t *testing.T) {
 	a := assert.New(t)
 
 	mockXero(func(ts *httptest.Server) {
-		provider := xeroProvider()
+		provider := mockProvider(ts)
 		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
 
 		additionalHeaders := map[string]string{
@@ -121,12 +125,196 @@ func Test_Find(t *testing.T) {
 
 }
 
+func Test_FindContext_Cancelled(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockProvider(ts)
+		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+
+		additionalHeaders := map[string]string{
+			"Accept": "application/json",
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		response, err := provider.FindContext(ctx, &session, "TrackingCategories", additionalHeaders, nil)
+		a.Nil(response)
+		a.True(errors.Is(err, context.Canceled))
+	})
+}
+
+func Test_FindStream(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockProvider(ts)
+		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+
+		additionalHeaders := map[string]string{
+			"Accept": "application/json",
+		}
+
+		body, err := provider.FindStream(&session, "TrackingCategories", additionalHeaders, nil)
+		a.NoError(err)
+		defer body.Close()
+
+		var testResponse *Tests
+		a.NoError(json.NewDecoder(body).Decode(&testResponse))
+		a.Equal("Store", testResponse.Tests[0].Name)
+
+		_, err = provider.FindStream(&session, "DailyLimit", additionalHeaders, nil)
+		a.True(IsRateLimited(err))
+	})
+}
+
+func Test_Send(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		a.Equal("PATCH", req.Method)
+		a.Equal("/api.xro/2.0/Things/1", req.URL.Path)
+		a.Equal("yes", req.URL.Query().Get("flag"))
+		a.Equal("text/plain", req.Header.Get("Content-Type"))
+		a.Equal("content", string(body))
+		fmt.Fprint(res, `{}`)
+	}))
+	defer ts.Close()
+
+	provider := xeroProvider()
+	provider.Endpoints = EndpointsForBaseURL(ts.URL)
+	session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+
+	response, err := provider.Send(&session, "PATCH", "Things/1", map[string]string{"Content-Type": "text/plain"}, map[string]string{"flag": "yes"}, []byte("content"))
+	a.NoError(err)
+	a.Equal("{}", string(response))
+}
+
+func Test_Find_RetriesRateLimitedRequest(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockProvider(ts)
+		provider.RetryPolicy = &RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+
+		additionalHeaders := map[string]string{
+			"Accept": "application/json",
+		}
+
+		response, err := provider.Find(&session, "RateLimited", additionalHeaders, nil)
+		a.NoError(err)
+
+		var testResponse *Tests
+		err = json.Unmarshal(response, &testResponse)
+		a.NoError(err)
+		a.Equal("Store", testResponse.Tests[0].Name)
+
+		rateLimit := provider.RateLimit("")
+		a.Equal(59, rateLimit.MinuteRemaining)
+		a.Equal(4999, rateLimit.DayRemaining)
+		a.Equal(-1, rateLimit.AppMinuteRemaining)
+	})
+}
+
+func Test_Find_DoesNotRetryDailyLimit(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := mockProvider(ts)
+		provider.RetryPolicy = DefaultRetryPolicy()
+		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+
+		additionalHeaders := map[string]string{
+			"Accept": "application/json",
+		}
+
+		start := time.Now()
+		_, err := provider.Find(&session, "DailyLimit", additionalHeaders, nil)
+		a.Error(err)
+		a.True(time.Since(start) < time.Second)
+
+		rateLimit := provider.RateLimit("")
+		a.Equal("day", rateLimit.Problem)
+		a.Equal(0, rateLimit.DayRemaining)
+		a.Equal(time.Hour, rateLimit.RetryAfter)
+	})
+}
+
+func Test_RateLimit_PerTenant(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("headers") == "yes" {
+			res.Header().Set("X-MinLimit-Remaining", "58")
+			res.Header().Set("X-DayLimit-Remaining", "4990")
+			res.Header().Set("X-AppMinLimit-Remaining", "9000")
+			if req.Header.Get("Xero-tenant-id") == "tenant-2" {
+				res.Header().Set("X-MinLimit-Remaining", "12")
+				res.Header().Set("X-AppMinLimit-Remaining", "8999")
+			}
+		}
+		fmt.Fprint(res, `{}`)
+	}))
+	defer ts.Close()
+
+	provider := xeroOAuth2Provider()
+	provider.Endpoints = EndpointsForBaseURL(ts.URL)
+	expires := time.Now().UTC().Add(30 * time.Minute)
+	first := &Session{OAuth2AccessToken: "ACCESS", TenantID: "tenant-1", AccessTokenExpires: expires}
+	second := &Session{OAuth2AccessToken: "ACCESS", TenantID: "tenant-2", AccessTokenExpires: expires}
+
+	a.Equal(-1, provider.RateLimit("tenant-1").MinuteRemaining)
+
+	_, err := provider.Find(first, "Things", nil, map[string]string{"headers": "yes"})
+	a.NoError(err)
+	_, err = provider.Find(second, "Things", nil, map[string]string{"headers": "yes"})
+	a.NoError(err)
+	_, err = provider.Find(first, "Things", nil, nil)
+	a.NoError(err)
+
+	rateLimit := provider.RateLimit("tenant-1")
+	a.Equal(58, rateLimit.MinuteRemaining, "a response without headers must not overwrite the limits")
+	a.Equal(4990, rateLimit.DayRemaining)
+	a.Equal(8999, rateLimit.AppMinuteRemaining, "the application limit is shared by every organisation")
+	a.Equal(12, provider.RateLimit("tenant-2").MinuteRemaining)
+	a.Equal(-1, provider.RateLimit("tenant-3").DayRemaining)
+}
+
+func Test_RetryPolicy_Backoff(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	policy := &RetryPolicy{MaxRetries: 5, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := policy.backoff(attempt)
+		a.True(wait >= 50*time.Millisecond, "backoff %s too short", wait)
+		a.True(wait <= time.Second, "backoff %s too long", wait)
+	}
+
+	request := httptest.NewRequest("PUT", "/api.xro/2.0/Invoices", nil)
+	_, retry := policy.delay(0, request, &http.Response{StatusCode: http.StatusInternalServerError}, RateLimit{})
+	a.False(retry)
+	_, retry = policy.delay(0, request, &http.Response{StatusCode: http.StatusServiceUnavailable}, RateLimit{})
+	a.True(retry)
+	_, retry = policy.delay(5, request, &http.Response{StatusCode: http.StatusServiceUnavailable}, RateLimit{})
+	a.False(retry)
+}
+
 func Test_Create(t *testing.T) {
 	t.Parallel()
 	a := assert.New(t)
 
 	mockXero(func(ts *httptest.Server) {
-		provider := xeroProvider()
+		provider := mockProvider(ts)
 		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
 
 		additionalHeaders := map[string]string{
@@ -175,7 +363,7 @@ func Test_Update(t *testing.T) {
 	a := assert.New(t)
 
 	mockXero(func(ts *httptest.Server) {
-		provider := xeroProvider()
+		provider := mockProvider(ts)
 		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
 
 		additionalHeaders := map[string]string{
@@ -223,7 +411,7 @@ func Test_Remove(t *testing.T) {
 	a := assert.New(t)
 
 	mockXero(func(ts *httptest.Server) {
-		provider := xeroProvider()
+		provider := mockProvider(ts)
 		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
 
 		additionalHeaders := map[string]string{
@@ -254,6 +442,13 @@ func xeroProvider() *Provider {
 	return New(os.Getenv("XERO_KEY"), os.Getenv("XERO_SECRET"), "/foo")
 }
 
+//mockProvider is a provider that talks to the server started by mockXero
+func mockProvider(ts *httptest.Server) *Provider {
+	provider := xeroProvider()
+	provider.Endpoints = EndpointsForBaseURL(ts.URL)
+	return provider
+}
+
 func mockXero(f func(*httptest.Server)) {
 	p := pat.New()
 	p.Get("/oauth/RequestToken", func(res http.ResponseWriter, req *http.Request) {
@@ -339,25 +534,114 @@ func mockXero(f func(*httptest.Server)) {
 		res.Write(js)
 	})
 
-	ts := httptest.NewServer(p)
-	defer ts.Close()
+	rateLimitedCalls := 0
+	p.Get("/api.xro/2.0/RateLimited", func(res http.ResponseWriter, req *http.Request) {
+		rateLimitedCalls++
+		if rateLimitedCalls == 1 {
+			res.Header().Set("X-Rate-Limit-Problem", "minute")
+			res.Header().Set("Retry-After", "0")
+			res.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
 
-	originalRequestURL := requestURL
-	originalEndpointProfile := endpointProfile
-	originalAuthorizeURL := authorizeURL
-	originalAccessTokenURL := tokenURL
+		apiResponse := Tests{
+			Tests: []Test{
+				{"111-111", "Store", "ACTIVE"},
+			},
+		}
 
-	requestURL = ts.URL + "/oauth/RequestToken"
-	endpointProfile = ts.URL + "/api.xro/2.0/"
-	authorizeURL = ts.URL + "/oauth/Authorize"
-	tokenURL = ts.URL + "/oauth/AccessToken"
+		js, err := json.Marshal(apiResponse)
+		if err != nil {
+			fmt.Fprint(res, "Json did not Marshal")
+		}
 
-	f(ts)
+		res.Header().Set("X-MinLimit-Remaining", "59")
+		res.Header().Set("X-DayLimit-Remaining", "4999")
+		res.Write(js)
+	})
+	p.Get("/api.xro/2.0/DailyLimit", func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Set("X-Rate-Limit-Problem", "day")
+		res.Header().Set("X-DayLimit-Remaining", "0")
+		res.Header().Set("Retry-After", "3600")
+		res.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	p.Get("/api.xro/2.0/Missing", func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(res, "The resource you're looking for cannot be found")
+	})
 
-	requestURL = originalRequestURL
-	endpointProfile = originalEndpointProfile
-	authorizeURL = originalAuthorizeURL
-	tokenURL = originalAccessTokenURL
+	p.Get("/api.xro/2.0/Bearer", func(res http.ResponseWriter, req *http.Request) {
+		apiResponse := Tests{
+			Tests: []Test{
+				{"111-111", req.Header.Get("Authorization"), "ACTIVE"},
+			},
+		}
+
+		js, err := json.Marshal(apiResponse)
+		if err != nil {
+			fmt.Fprint(res, "Json did not Marshal")
+		}
+
+		res.Write(js)
+	})
+	p.Get("/api.xro/2.0/Tenant", func(res http.ResponseWriter, req *http.Request) {
+		apiResponse := Tests{
+			Tests: []Test{
+				{"111-111", req.Header.Get("Xero-tenant-id"), "ACTIVE"},
+			},
+		}
+
+		js, err := json.Marshal(apiResponse)
+		if err != nil {
+			fmt.Fprint(res, "Json did not Marshal")
+		}
+
+		res.Write(js)
+	})
+	p.Get("/connections", func(res http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") == "" {
+			res.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(res, `[
+			{"id":"conn-1","tenantId":"tenant-1","tenantType":"ORGANISATION","tenantName":"Vanderlay Industries"},
+			{"id":"conn-2","tenantId":"tenant-2","tenantType":"ORGANISATION","tenantName":"Kramerica"}
+		]`)
+	})
+	p.Delete("/connections/{ID}", func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusNoContent)
+	})
+	p.Post("/connect/token", func(res http.ResponseWriter, req *http.Request) {
+		clientID, secret, ok := req.BasicAuth()
+		if !ok || clientID != "CLIENT" || secret != "SECRET" {
+			res.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(res, `{"error":"invalid_client"}`)
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.FormValue("grant_type") == "authorization_code" && req.FormValue("code") == "CODE":
+			fmt.Fprint(res, `{"access_token":"ACCESS","refresh_token":"REFRESH","expires_in":1800,"token_type":"Bearer"}`)
+		case req.FormValue("grant_type") == "refresh_token" && req.FormValue("refresh_token") == "REFRESH":
+			fmt.Fprint(res, `{"access_token":"REFRESHED","refresh_token":"REFRESH2","expires_in":1800,"token_type":"Bearer"}`)
+		default:
+			res.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(res, `{"error":"invalid_grant"}`)
+		}
+	})
+	p.Post("/connect/revocation", func(res http.ResponseWriter, req *http.Request) {
+		if req.FormValue("token") != "REFRESH" {
+			res.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(res, `{"error":"invalid_request"}`)
+		}
+	})
+
+	ts := httptest.NewServer(p)
+	defer ts.Close()
+
+	f(ts)
 }
 
 //Test is a tracking category -  we're just testing how the API responds here